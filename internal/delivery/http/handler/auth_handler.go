@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -35,7 +37,7 @@ func (h *AuthHandler) SignUp(c *gin.Context) {
 
 	response, err := h.authUseCase.SignUp(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -72,6 +74,119 @@ func (h *AuthHandler) SignIn(c *gin.Context) {
 	})
 }
 
+// Refresh リフレッシュトークンによるトークン更新
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req entity.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.authUseCase.Refresh(&req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "トークンを更新しました",
+		"data":    response,
+	})
+}
+
+// SignOut サインアウト（現在のアクセストークンを失効）
+func (h *AuthHandler) SignOut(c *gin.Context) {
+	// リクエストボディは任意
+	var req entity.SignOutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	if err := h.authUseCase.SignOut(userID.(uint), c.GetString("token_id"), c.GetTime("token_expires_at"), &req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "サインアウトしました"})
+}
+
+// SignOutAll 全端末からサインアウト
+func (h *AuthHandler) SignOutAll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	if err := h.authUseCase.SignOutAll(userID.(uint)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "すべての端末からサインアウトしました"})
+}
+
+// IssueStreamTicket リアルタイム配信（EventSource）の接続用チケットを発行
+func (h *AuthHandler) IssueStreamTicket(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	ticket, err := h.authUseCase.IssueStreamTicket(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": ticket})
+}
+
+// ChangePassword パスワード変更
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req entity.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	response, err := h.authUseCase.ChangePassword(userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "パスワードを変更しました",
+		"data":    response,
+	})
+}
+
 // DebugToken JWTトークンのデバッグ用エンドポイント
 func (h *AuthHandler) DebugToken(c *gin.Context) {
 	// Authorizationヘッダーからトークンを取得
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"sidemenulab-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actorFromContext 認証ミドルウェアが設定したユーザー情報から Actor を組み立てる
+func actorFromContext(c *gin.Context) (*entity.Actor, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return nil, false
+	}
+
+	role := c.GetString("user_role")
+	if role == "" {
+		role = entity.RoleUser
+	}
+
+	return &entity.Actor{
+		UserID: userID.(uint),
+		Role:   role,
+	}, true
+}
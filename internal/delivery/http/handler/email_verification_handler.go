@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationUseCase interfaces.EmailVerificationUseCase
+}
+
+func NewEmailVerificationHandler(emailVerificationUseCase interfaces.EmailVerificationUseCase) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerificationUseCase: emailVerificationUseCase,
+	}
+}
+
+// VerifyEmail メールアドレス確認
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	var req entity.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.emailVerificationUseCase.VerifyEmail(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "メールアドレスの確認が完了しました"})
+}
+
+// ResendVerificationEmail 確認メール再送
+func (h *EmailVerificationHandler) ResendVerificationEmail(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	if err := h.emailVerificationUseCase.ResendVerificationEmail(userID.(uint)); err != nil {
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "確認メールを再送しました"})
+}
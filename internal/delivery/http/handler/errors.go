@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// statusFromError ユースケースのエラーからHTTPステータスを決定する
+// 既知のエラーでない場合は fallback を返す
+func statusFromError(err error, fallback int) int {
+	switch {
+	case errors.Is(err, entity.ErrEmailNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrTooManyRequests):
+		return http.StatusTooManyRequests
+	case errors.Is(err, entity.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrInvalidCriteria), errors.Is(err, entity.ErrInvalidCursor), errors.Is(err, entity.ErrInvalidRequest), errors.Is(err, entity.ErrInvalidImage):
+		return http.StatusBadRequest
+	case errors.Is(err, entity.ErrConflict):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
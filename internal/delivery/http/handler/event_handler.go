@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/infrastructure/realtime"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeatInterval 接続を維持するため、イベントがなくてもコメント行を送る間隔
+const heartbeatInterval = 25 * time.Second
+
+type EventHandler struct {
+	eventHub      realtime.Hub
+	reviewUseCase interfaces.ReviewUseCase
+}
+
+func NewEventHandler(eventHub realtime.Hub, reviewUseCase interfaces.ReviewUseCase) *EventHandler {
+	return &EventHandler{
+		eventHub:      eventHub,
+		reviewUseCase: reviewUseCase,
+	}
+}
+
+// StreamReviewEvents レビューへのコメント・いいね・更新を Server-Sent Events で配信
+func (h *EventHandler) StreamReviewEvents(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	if _, err := h.reviewUseCase.GetReviewByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "レビューが見つかりません"})
+		return
+	}
+
+	h.stream(c, entity.ReviewTopic(uint(id)))
+}
+
+// StreamNotificationEvents 自分宛ての通知を Server-Sent Events で配信
+func (h *EventHandler) StreamNotificationEvents(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	h.stream(c, entity.UserNotificationTopic(userID.(uint)))
+}
+
+// stream クライアントが切断するまでトピックのイベントを書き込む
+func (h *EventHandler) stream(c *gin.Context, topic string) {
+	sub := h.eventHub.Subscribe(topic)
+	defer sub.Close()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// リバースプロキシでのバッファリングを無効にする
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.SSEvent("ready", gin.H{"topic": topic})
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event.Data)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationUseCase interfaces.NotificationUseCase
+}
+
+func NewNotificationHandler(notificationUseCase interfaces.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// ListNotifications 自分宛ての通知一覧取得
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	unreadOnly, err := queryBool(c, "unread_only")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	notifications, pageInfo, err := h.notificationUseCase.ListNotifications(userID.(uint), unreadOnly, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": notifications, "pagination": pageInfo})
+}
+
+// CountUnread 未読の通知件数取得
+func (h *NotificationHandler) CountUnread(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	count, err := h.notificationUseCase.CountUnread(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"unread_count": count}})
+}
+
+// MarkRead 通知を既読にする
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	if err := h.notificationUseCase.MarkRead(userID.(uint), uint(id)); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "通知を既読にしました"})
+}
+
+// MarkAllRead 自分宛ての通知をすべて既読にする
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	updated, err := h.notificationUseCase.MarkAllRead(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "すべての通知を既読にしました", "data": gin.H{"updated": updated}})
+}
+
+// GetPreferences 通知の受信設定取得
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	prefs, err := h.notificationUseCase.GetPreferences(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": prefs})
+}
+
+// UpdatePreferences 通知の受信設定変更（指定した種類のみ変更する）
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req entity.NotificationPreferences
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	prefs, err := h.notificationUseCase.UpdatePreferences(userID.(uint), req)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "通知の受信設定を更新しました", "data": prefs})
+}
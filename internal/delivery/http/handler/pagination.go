@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"strconv"
+
+	"sidemenulab-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePageRequest クエリパラメータ cursor / limit からページ指定を組み立てる
+func parsePageRequest(c *gin.Context) (entity.PageRequest, error) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			return entity.PageRequest{}, entity.ErrInvalidCursor
+		}
+		limit = l
+	}
+
+	var cursor *entity.Cursor
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		decoded, err := entity.DecodeCursor(cursorStr)
+		if err != nil {
+			return entity.PageRequest{}, err
+		}
+		cursor = decoded
+	}
+
+	return entity.NewPageRequest(cursor, limit), nil
+}
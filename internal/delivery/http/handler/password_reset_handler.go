@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetHandler struct {
+	passwordResetUseCase interfaces.PasswordResetUseCase
+}
+
+func NewPasswordResetHandler(passwordResetUseCase interfaces.PasswordResetUseCase) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetUseCase: passwordResetUseCase,
+	}
+}
+
+// ForgotPassword パスワードリセットメール送信
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var req entity.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.passwordResetUseCase.ForgotPassword(&req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 登録有無に関わらず同じレスポンスを返す
+	c.JSON(http.StatusOK, gin.H{"message": "登録されているメールアドレスの場合、パスワード再設定用のメールを送信しました"})
+}
+
+// ResetPassword パスワード再設定
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var req entity.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.passwordResetUseCase.ResetPassword(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "パスワードを再設定しました。新しいパスワードでログインしてください"})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RankingHandler struct {
+	rankingUseCase interfaces.RankingUseCase
+}
+
+func NewRankingHandler(rankingUseCase interfaces.RankingUseCase) *RankingHandler {
+	return &RankingHandler{
+		rankingUseCase: rankingUseCase,
+	}
+}
+
+// GetSideMenuRanking サイドメニューランキング取得
+func (h *RankingHandler) GetSideMenuRanking(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, rankings, err := h.rankingUseCase.GetSideMenuRanking(c.Query("category"), limit)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         entries,
+		"min_reviews":  rankings.MinReviews,
+		"generated_at": rankings.GeneratedAt,
+	})
+}
+
+// GetStoreRanking 店舗ランキング取得
+func (h *RankingHandler) GetStoreRanking(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, rankings, err := h.rankingUseCase.GetStoreRanking(c.Query("category"), limit)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         entries,
+		"min_reviews":  rankings.MinReviews,
+		"generated_at": rankings.GeneratedAt,
+	})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReactionHandler struct {
+	reactionUseCase interfaces.ReactionUseCase
+}
+
+func NewReactionHandler(reactionUseCase interfaces.ReactionUseCase) *ReactionHandler {
+	return &ReactionHandler{
+		reactionUseCase: reactionUseCase,
+	}
+}
+
+// ListReactionTypes 利用できるリアクション一覧取得
+func (h *ReactionHandler) ListReactionTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.reactionUseCase.ListReactionTypes()})
+}
+
+// ToggleReviewReaction レビューのリアクション切り替え
+func (h *ReactionHandler) ToggleReviewReaction(c *gin.Context) {
+	h.toggleReaction(c, entity.ReactionTargetReview)
+}
+
+// ToggleCommentReaction コメントのリアクション切り替え
+func (h *ReactionHandler) ToggleCommentReaction(c *gin.Context) {
+	h.toggleReaction(c, entity.ReactionTargetComment)
+}
+
+func (h *ReactionHandler) toggleReaction(c *gin.Context, targetType entity.ReactionTargetType) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	var req entity.ToggleReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	result, err := h.reactionUseCase.ToggleReaction(targetType, uint(id), req.Type, userID.(uint))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "リアクションを取り消しました"
+	if result.Reacted {
+		message = "リアクションしました"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "data": result})
+}
+
+// viewerID 認証済みのリクエストの場合はユーザーID、未認証の場合は 0 を返す
+func viewerID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
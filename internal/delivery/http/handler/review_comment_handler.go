@@ -12,11 +12,13 @@ import (
 
 type ReviewCommentHandler struct {
 	reviewCommentUseCase interfaces.ReviewCommentUseCase
+	reactionUseCase      interfaces.ReactionUseCase
 }
 
-func NewReviewCommentHandler(reviewCommentUseCase interfaces.ReviewCommentUseCase) *ReviewCommentHandler {
+func NewReviewCommentHandler(reviewCommentUseCase interfaces.ReviewCommentUseCase, reactionUseCase interfaces.ReactionUseCase) *ReviewCommentHandler {
 	return &ReviewCommentHandler{
 		reviewCommentUseCase: reviewCommentUseCase,
+		reactionUseCase:      reactionUseCase,
 	}
 }
 
@@ -31,6 +33,7 @@ func (h *ReviewCommentHandler) CreateReviewComment(c *gin.Context) {
 			"received_data": gin.H{
 				"review_id": req.ReviewID,
 				"comment": req.Comment,
+				"parent_id": req.ParentID,
 			},
 		})
 		return
@@ -45,7 +48,7 @@ func (h *ReviewCommentHandler) CreateReviewComment(c *gin.Context) {
 
 	comment, err := h.reviewCommentUseCase.CreateReviewComment(&req, userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -67,6 +70,11 @@ func (h *ReviewCommentHandler) GetReviewCommentByID(c *gin.Context) {
 		return
 	}
 
+	if err := h.decorateComments(c, comment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"data": comment})
 }
 
@@ -79,13 +87,59 @@ func (h *ReviewCommentHandler) GetReviewCommentsByReviewID(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.reviewCommentUseCase.GetReviewCommentsByReviewID(uint(reviewID))
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	mode, err := entity.ParseReviewCommentMode(c.Query("mode"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, pageInfo, err := h.reviewCommentUseCase.GetReviewCommentsByReviewID(uint(reviewID), mode, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": comments})
+	if err := h.decorateComments(c, flattenComments(comments)...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comments, "pagination": pageInfo})
+}
+
+// GetReplies コメントへの返信一覧取得
+func (h *ReviewCommentHandler) GetReplies(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	replies, pageInfo, err := h.reviewCommentUseCase.GetReplies(uint(id), page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateComments(c, replies...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": replies, "pagination": pageInfo})
 }
 
 // GetReviewCommentsByUserID ユーザー別コメント一覧取得
@@ -97,24 +151,74 @@ func (h *ReviewCommentHandler) GetReviewCommentsByUserID(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.reviewCommentUseCase.GetReviewCommentsByUserID(uint(userID))
+	page, err := parsePageRequest(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	comments, pageInfo, err := h.reviewCommentUseCase.GetReviewCommentsByUserID(uint(userID), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateComments(c, comments...); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": comments})
+	c.JSON(http.StatusOK, gin.H{"data": comments, "pagination": pageInfo})
+}
+
+// GetMyMentions 自分をメンションしているコメント一覧取得
+func (h *ReviewCommentHandler) GetMyMentions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	comments, pageInfo, err := h.reviewCommentUseCase.GetMentionsOfUser(userID.(uint), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateComments(c, comments...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comments, "pagination": pageInfo})
 }
 
 // GetAllReviewComments 全コメント一覧取得
 func (h *ReviewCommentHandler) GetAllReviewComments(c *gin.Context) {
-	comments, err := h.reviewCommentUseCase.GetAllReviewComments()
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	comments, pageInfo, err := h.reviewCommentUseCase.GetAllReviewComments(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": comments})
+	if err := h.decorateComments(c, comments...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comments, "pagination": pageInfo})
 }
 
 // UpdateReviewComment レビューコメント更新
@@ -126,42 +230,27 @@ func (h *ReviewCommentHandler) UpdateReviewComment(c *gin.Context) {
 		return
 	}
 
-	var req entity.ReviewComment
+	var req entity.UpdateReviewCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// 認証されたユーザーIDを取得
-	userID, exists := c.Get("user_id")
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
 		return
 	}
 
-	// 既存のコメントを取得してユーザーIDを確認
-	existingComment, err := h.reviewCommentUseCase.GetReviewCommentByID(uint(id))
+	// コメントの所有者（またはモデレーター）かどうかはユースケースで確認する
+	comment, err := h.reviewCommentUseCase.UpdateReviewComment(uint(id), &req, actor)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "レビューコメントが見つかりません"})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	// コメントの所有者かどうかを確認
-	if existingComment.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "このコメントを編集する権限がありません"})
-		return
-	}
-
-	req.ID = uint(id)
-	req.UserID = userID.(uint)
-	req.ReviewID = existingComment.ReviewID
-
-	if err := h.reviewCommentUseCase.UpdateReviewComment(&req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "レビューコメントが更新されました"})
+	c.JSON(http.StatusOK, gin.H{"message": "レビューコメントが更新されました", "data": comment})
 }
 
 // DeleteReviewComment レビューコメント削除
@@ -173,30 +262,32 @@ func (h *ReviewCommentHandler) DeleteReviewComment(c *gin.Context) {
 		return
 	}
 
-	// 認証されたユーザーIDを取得
-	userID, exists := c.Get("user_id")
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
 		return
 	}
 
-	// 既存のコメントを取得してユーザーIDを確認
-	existingComment, err := h.reviewCommentUseCase.GetReviewCommentByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "レビューコメントが見つかりません"})
+	if err := h.reviewCommentUseCase.DeleteReviewComment(uint(id), actor); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	// コメントの所有者かどうかを確認
-	if existingComment.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "このコメントを削除する権限がありません"})
-		return
-	}
+	c.JSON(http.StatusOK, gin.H{"message": "レビューコメントが削除されました"})
+}
 
-	if err := h.reviewCommentUseCase.DeleteReviewComment(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
+// decorateComments 各コメントにリアクションの件数と、認証済みの場合はそのユーザーのリアクションを設定する
+func (h *ReviewCommentHandler) decorateComments(c *gin.Context, comments ...*entity.ReviewComment) error {
+	return h.reactionUseCase.AttachCommentReactions(comments, viewerID(c))
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "レビューコメントが削除されました"})
+// flattenComments ツリー形式のコメントを返信も含めて1列に並べる
+func flattenComments(comments []*entity.ReviewComment) []*entity.ReviewComment {
+	var flat []*entity.ReviewComment
+	for _, comment := range comments {
+		flat = append(flat, comment)
+		flat = append(flat, flattenComments(comment.Replies)...)
+	}
+	return flat
 }
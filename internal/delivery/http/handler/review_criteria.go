@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseReviewCriteria クエリパラメータからレビューの絞り込み条件を組み立てる
+func parseReviewCriteria(c *gin.Context) (entity.ReviewCriteria, error) {
+	criteria := entity.ReviewCriteria{
+		StoreName:    c.Query("store"),
+		SideMenuName: c.Query("side_menu"),
+		Sort:         entity.ReviewSort(c.Query("sort")),
+	}
+
+	var err error
+	if criteria.StoreID, err = queryID(c, "store_id"); err != nil {
+		return criteria, err
+	}
+	if criteria.SideMenuID, err = queryID(c, "side_menu_id"); err != nil {
+		return criteria, err
+	}
+	if criteria.UserID, err = queryID(c, "user_id"); err != nil {
+		return criteria, err
+	}
+	if criteria.MinRating, err = queryInt(c, "min_rating"); err != nil {
+		return criteria, err
+	}
+	if criteria.MaxRating, err = queryInt(c, "max_rating"); err != nil {
+		return criteria, err
+	}
+	if criteria.VerifiedOnly, err = queryBool(c, "verified_only"); err != nil {
+		return criteria, err
+	}
+	if criteria.HasImages, err = queryBool(c, "has_images"); err != nil {
+		return criteria, err
+	}
+	if criteria.From, err = queryDate(c, "from", false); err != nil {
+		return criteria, err
+	}
+	if criteria.To, err = queryDate(c, "to", true); err != nil {
+		return criteria, err
+	}
+
+	return criteria, nil
+}
+
+func queryInt(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s が正しくありません", entity.ErrInvalidCriteria, key)
+	}
+	return n, nil
+}
+
+func queryID(c *gin.Context, key string) (uint, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s が正しくありません", entity.ErrInvalidCriteria, key)
+	}
+	return uint(id), nil
+}
+
+func queryBool(c *gin.Context, key string) (bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s が正しくありません", entity.ErrInvalidCriteria, key)
+	}
+	return b, nil
+}
+
+// queryDate RFC3339 または YYYY-MM-DD 形式の日時を読み取る
+// endOfDay が true の場合、日付のみの指定はその日の終わり（翌日0時）として扱う。
+func queryDate(c *gin.Context, key string, endOfDay bool) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s は RFC3339 または YYYY-MM-DD 形式で指定してください", entity.ErrInvalidCriteria, key)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -1,30 +1,36 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
 	"net/http"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"sidemenulab-backend/internal/domain/entity"
-	"sidemenulab-backend/internal/infrastructure/cloudinary"
+	"sidemenulab-backend/internal/infrastructure/imaging"
+	"sidemenulab-backend/internal/infrastructure/storage"
 	"sidemenulab-backend/internal/usecase/interfaces"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ReviewHandler struct {
-	reviewUseCase     interfaces.ReviewUseCase
-	cloudinaryService *cloudinary.CloudinaryService
+	reviewUseCase   interfaces.ReviewUseCase
+	reactionUseCase interfaces.ReactionUseCase
+	imageStorage    interfaces.ImageStorage
 }
 
-func NewReviewHandler(reviewUseCase interfaces.ReviewUseCase, cloudinaryService *cloudinary.CloudinaryService) *ReviewHandler {
+func NewReviewHandler(reviewUseCase interfaces.ReviewUseCase, reactionUseCase interfaces.ReactionUseCase, imageStorage interfaces.ImageStorage) *ReviewHandler {
 	return &ReviewHandler{
-		reviewUseCase:     reviewUseCase,
-		cloudinaryService: cloudinaryService,
+		reviewUseCase:   reviewUseCase,
+		reactionUseCase: reactionUseCase,
+		imageStorage:    imageStorage,
 	}
 }
 
@@ -47,7 +53,7 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 
 	review, err := h.reviewUseCase.CreateReviewWithUserID(&req, userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -69,6 +75,11 @@ func (h *ReviewHandler) GetReviewByID(c *gin.Context) {
 		return
 	}
 
+	if err := h.decorateReviews(c, review); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"data": review})
 }
 
@@ -80,13 +91,53 @@ func (h *ReviewHandler) GetReviewsByStoreName(c *gin.Context) {
 		return
 	}
 
-	reviews, err := h.reviewUseCase.GetReviewsByStoreName(storeName)
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	reviews, pageInfo, err := h.reviewUseCase.GetReviewsByStoreName(storeName, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": reviews})
+	if err := h.decorateReviews(c, reviews...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reviews, "pagination": pageInfo})
+}
+
+// GetReviewsBySideMenuID サイドメニュー別レビュー一覧取得
+func (h *ReviewHandler) GetReviewsBySideMenuID(c *gin.Context) {
+	sideMenuIDStr := c.Param("sideMenuId")
+	sideMenuID, err := strconv.ParseUint(sideMenuIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なサイドメニューIDです"})
+		return
+	}
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	reviews, pageInfo, err := h.reviewUseCase.GetReviewsBySideMenuID(uint(sideMenuID), page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateReviews(c, reviews...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reviews, "pagination": pageInfo})
 }
 
 // GetLikedReviewsByUserID ユーザーがいいねしたレビュー一覧取得
@@ -98,24 +149,81 @@ func (h *ReviewHandler) GetLikedReviewsByUserID(c *gin.Context) {
 		return
 	}
 
-	reviews, err := h.reviewUseCase.GetLikedReviewsByUserID(userID.(uint))
+	page, err := parsePageRequest(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	reviews, pageInfo, err := h.reviewUseCase.GetLikedReviewsByUserID(userID.(uint), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateReviews(c, reviews...); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": reviews})
+	c.JSON(http.StatusOK, gin.H{"data": reviews, "pagination": pageInfo})
 }
 
-// GetAllReviews レビュー一覧取得
+// GetAllReviews レビュー一覧取得（絞り込み・並び替え）
 func (h *ReviewHandler) GetAllReviews(c *gin.Context) {
-	reviews, err := h.reviewUseCase.GetAllReviews()
+	criteria, err := parseReviewCriteria(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := parsePageRequest(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	reviews, pageInfo, err := h.reviewUseCase.SearchReviews(criteria, page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateReviews(c, reviews...); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": reviews})
+	c.JSON(http.StatusOK, gin.H{"data": reviews, "pagination": pageInfo})
+}
+
+// GetTrendingReviews トレンドのレビュー一覧取得（いいね・コメントの多さと新しさで並べる）
+func (h *ReviewHandler) GetTrendingReviews(c *gin.Context) {
+	criteria, err := parseReviewCriteria(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	criteria.Sort = entity.ReviewSortTrending
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	reviews, pageInfo, err := h.reviewUseCase.SearchReviews(criteria, page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.decorateReviews(c, reviews...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reviews, "pagination": pageInfo})
 }
 
 // CreateReviewImage レビュー画像アップロード
@@ -135,9 +243,16 @@ func (h *ReviewHandler) CreateReviewImage(c *gin.Context) {
 
 	req.ReviewID = uint(id)
 
-	image, err := h.reviewUseCase.CreateReviewImage(&req)
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	image, err := h.reviewUseCase.CreateReviewImage(&req, actor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -153,15 +268,34 @@ func (h *ReviewHandler) UploadReviewImages(c *gin.Context) {
 		return
 	}
 
-	// CloudinaryServiceが利用できない場合のエラーハンドリング
-	if h.cloudinaryService == nil {
+	// 画像の保存先が設定されていない場合のエラーハンドリング
+	if h.imageStorage == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "画像アップロードサービスが利用できません"})
 		return
 	}
 
-	// マルチパートフォームを解析
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	// アップロード前にレビューへ画像を追加する権限を確認
+	if err := h.reviewUseCase.AuthorizeReviewImageUpload(uint(id), actor); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	// マルチパートフォームを解析（ファイル数の上限分を超える大きさのリクエストは読み込まない）
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImageUploadRequestBytes)
 	form, err := c.MultipartForm()
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("リクエストが大きすぎます（画像は1回に%d枚、1ファイル%dMB以下にしてください）", entity.MaxReviewImagesPerUpload, entity.MaxReviewImageBytes/1024/1024)})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "マルチパートフォームの解析に失敗しました"})
 		return
 	}
@@ -171,55 +305,65 @@ func (h *ReviewHandler) UploadReviewImages(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "画像ファイルが選択されていません"})
 		return
 	}
+	if len(files) > entity.MaxReviewImagesPerUpload {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("画像は1回に%d枚までアップロードできます", entity.MaxReviewImagesPerUpload)})
+		return
+	}
 
-	var uploadedImages []*entity.SideMenuReviewImage
-	ctx := context.Background()
-
+	// すべてのファイルを検証してからアップロードする（不正なファイルが1つでもあれば何もアップロードしない）
+	// 保存先に関わらず、位置情報などのメタデータは保存前に取り除く
+	contents := make([][]byte, len(files))
+	var invalidFiles []*entity.InvalidImageFile
 	for i, file := range files {
-		// ファイル拡張子をチェック
-		ext := strings.ToLower(filepath.Ext(file.Filename))
-		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" && ext != ".gif" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ファイル %s はサポートされていない形式です", file.Filename)})
-			return
-		}
-
-		// ファイルサイズをチェック (5MB制限)
-		if file.Size > 5*1024*1024 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ファイル %s が大きすぎます（5MB以下にしてください）", file.Filename)})
-			return
-		}
-
-		// ファイルを開く
-		src, err := file.Open()
+		data, err := prepareImageFile(file)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("ファイル %s のオープンに失敗しました", file.Filename)})
-			return
+			invalidFiles = append(invalidFiles, &entity.InvalidImageFile{File: file.Filename, Error: err.Error()})
+			continue
 		}
-		defer src.Close()
+		contents[i] = data
+	}
+	if len(invalidFiles) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "画像ファイルの検証に失敗しました", "details": invalidFiles})
+		return
+	}
 
-		// Cloudinaryにアップロード
+	var uploadedImages []*entity.SideMenuReviewImage
+	ctx := context.Background()
+
+	for i := range files {
+		// 画像の保存先にアップロード
 		timestamp := time.Now().UnixNano()
-		publicID := cloudinary.GeneratePublicID(uint(id), timestamp, i)
-		folder := cloudinary.GenerateFolderPath()
+		name := storage.GenerateImageName(uint(id), timestamp, i)
+		folder := storage.GenerateFolderPath()
 
-		uploadResult, err := h.cloudinaryService.UploadImage(ctx, src, folder, publicID)
+		stored, err := h.imageStorage.Put(ctx, bytes.NewReader(contents[i]), folder, name)
 		if err != nil {
+			h.rollbackUploadedImages(uploadedImages, actor)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("画像のアップロードに失敗しました: %s", err.Error())})
 			return
 		}
 
 		// データベースに画像情報を保存
 		imageReq := &entity.CreateReviewImageRequest{
-			ReviewID:   uint(id),
-			ImageURL:   uploadResult.SecureURL, // CloudinaryのセキュアURLを使用
-			ImageOrder: i,
+			ReviewID:        uint(id),
+			ImageURL:        stored.URL,
+			ImageOrder:      i,
+			StorageProvider: h.imageStorage.Provider(),
+			StorageKey:      stored.Key,
+			Width:           stored.Width,
+			Height:          stored.Height,
+			Format:          stored.Format,
+			Bytes:           stored.Bytes,
 		}
 
-		image, err := h.reviewUseCase.CreateReviewImage(imageReq)
+		image, err := h.reviewUseCase.CreateReviewImage(imageReq, actor)
 		if err != nil {
-			// データベース保存に失敗した場合、Cloudinaryからも削除
-			h.cloudinaryService.DeleteImage(ctx, uploadResult.PublicID)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("画像情報の保存に失敗しました: %s", err.Error())})
+			// データベース保存に失敗した場合、保存先からも削除（削除できなかった画像は参照されていない画像として回収される）
+			if err := h.imageStorage.Delete(ctx, stored.Key); err != nil {
+				log.Printf("保存に失敗した画像の削除に失敗しました - Key: %s: %v", stored.Key, err)
+			}
+			h.rollbackUploadedImages(uploadedImages, actor)
+			c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": fmt.Sprintf("画像情報の保存に失敗しました: %s", err.Error())})
 			return
 		}
 
@@ -232,6 +376,51 @@ func (h *ReviewHandler) UploadReviewImages(c *gin.Context) {
 	})
 }
 
+// rollbackUploadedImages 途中で失敗したアップロードのうち、登録済みの画像を取り消す
+// 画像の行を削除すると保存先からの削除ジョブが登録されるため、保存先の画像も後で削除される。
+func (h *ReviewHandler) rollbackUploadedImages(images []*entity.SideMenuReviewImage, actor *entity.Actor) {
+	for _, image := range images {
+		if err := h.reviewUseCase.DeleteReviewImage(image.ID, actor); err != nil {
+			log.Printf("アップロードの取り消しに失敗しました - ImageID: %d: %v", image.ID, err)
+		}
+	}
+}
+
+// maxImageUploadRequestBytes 画像アップロードのリクエストの大きさの上限（ファイルの上限にマルチパートのヘッダー等の余裕を加える）
+const maxImageUploadRequestBytes = entity.MaxReviewImagesPerUpload*entity.MaxReviewImageBytes + 1024*1024
+
+// prepareImageFile アップロードされたファイルを読み込んで検証し、メタデータを取り除く
+func prepareImageFile(file *multipart.FileHeader) ([]byte, error) {
+	data, err := readImageFile(file)
+	if err != nil {
+		return nil, err
+	}
+	info, err := imaging.Validate(data, file.Filename, file.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	return imaging.Sanitize(data, info.Format)
+}
+
+// readImageFile アップロードされたファイルを読み込む
+// 申告されたサイズは信用せず、上限を超えて読み込めた場合はエラーにする。
+func readImageFile(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, errors.New("ファイルのオープンに失敗しました")
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, entity.MaxReviewImageBytes+1))
+	if err != nil {
+		return nil, errors.New("ファイルの読み込みに失敗しました")
+	}
+	if len(data) > entity.MaxReviewImageBytes {
+		return nil, fmt.Errorf("%w: ファイルが大きすぎます（%dMB以下にしてください）", entity.ErrInvalidImage, entity.MaxReviewImageBytes/1024/1024)
+	}
+	return data, nil
+}
+
 // GetReviewImagesByReviewID レビュー画像一覧取得
 func (h *ReviewHandler) GetReviewImagesByReviewID(c *gin.Context) {
 	idStr := c.Param("id")
@@ -250,7 +439,8 @@ func (h *ReviewHandler) GetReviewImagesByReviewID(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": images})
 }
 
-// CreateReviewLike レビューにイイネ
+// CreateReviewLike レビューにイイネ（PUT・POST 共通）
+// 同じユーザーが繰り返しいいねしても重複せず、2回目以降は既存のいいねを 200 で返す。
 func (h *ReviewHandler) CreateReviewLike(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
@@ -266,12 +456,16 @@ func (h *ReviewHandler) CreateReviewLike(c *gin.Context) {
 		return
 	}
 
-	like, err := h.reviewUseCase.CreateReviewLike(uint(id), userID.(uint))
+	like, created, err := h.reviewUseCase.CreateReviewLike(uint(id), userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"message": "既にイイネしています", "data": like})
+		return
+	}
 	c.JSON(http.StatusCreated, gin.H{"message": "レビューにイイネしました", "data": like})
 }
 
@@ -292,7 +486,7 @@ func (h *ReviewHandler) DeleteReviewLike(c *gin.Context) {
 	}
 
 	if err := h.reviewUseCase.DeleteReviewLike(uint(id), userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -308,13 +502,19 @@ func (h *ReviewHandler) GetReviewLikesByReviewID(c *gin.Context) {
 		return
 	}
 
-	likes, err := h.reviewUseCase.GetReviewLikesByReviewID(uint(id))
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	likes, pageInfo, err := h.reviewUseCase.GetReviewLikesByReviewID(uint(id), page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": likes})
+	c.JSON(http.StatusOK, gin.H{"data": likes, "pagination": pageInfo})
 }
 
 // UpdateReview レビュー編集
@@ -326,40 +526,23 @@ func (h *ReviewHandler) UpdateReview(c *gin.Context) {
 		return
 	}
 
-	// 認証されたユーザーIDを取得
-	userID, exists := c.Get("user_id")
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
 		return
 	}
 
-	// レビューの存在確認と所有者チェック
-	review, err := h.reviewUseCase.GetReviewByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "レビューが見つかりません"})
-		return
-	}
-
-	if review.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "このレビューを編集する権限がありません"})
-		return
-	}
-
 	var req entity.CreateReviewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// レビューを更新
-	review.StoreName = req.StoreName
-	review.SideMenuName = req.SideMenuName
-	review.Rating = req.Rating
-	review.Title = req.Title
-	review.Comment = req.Comment
-
-	if err := h.reviewUseCase.UpdateReview(review); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	// 所有者（またはモデレーター）かどうかはユースケースで確認する
+	review, err := h.reviewUseCase.UpdateReview(uint(id), &req, actor)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -375,27 +558,15 @@ func (h *ReviewHandler) DeleteReview(c *gin.Context) {
 		return
 	}
 
-	// 認証されたユーザーIDを取得
-	userID, exists := c.Get("user_id")
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
 		return
 	}
 
-	// レビューの存在確認と所有者チェック
-	review, err := h.reviewUseCase.GetReviewByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "レビューが見つかりません"})
-		return
-	}
-
-	if review.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "このレビューを削除する権限がありません"})
-		return
-	}
-
-	if err := h.reviewUseCase.DeleteReview(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.reviewUseCase.DeleteReview(uint(id), actor); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -411,21 +582,36 @@ func (h *ReviewHandler) DeleteReviewImage(c *gin.Context) {
 		return
 	}
 
-	// 認証されたユーザーIDを取得
-	_, exists := c.Get("user_id")
+	// 認証されたユーザーを取得
+	actor, exists := actorFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
 		return
 	}
 
-	// 画像削除の権限チェックは、画像が属するレビューの所有者であることを確認
-	// この実装では、画像削除の権限チェックを簡略化しています
-	// 実際のアプリケーションでは、より詳細な権限チェックが必要です
-
-	if err := h.reviewUseCase.DeleteReviewImage(uint(imageID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	// 画像が属するレビューの所有者（またはモデレーター）かどうかはユースケースで確認する
+	if err := h.reviewUseCase.DeleteReviewImage(uint(imageID), actor); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "レビュー画像が削除されました"})
 }
+
+func (h *ReviewHandler) decorateReviews(c *gin.Context, reviews ...*entity.SideMenuReview) error {
+	return decorateReviews(c, h.reviewUseCase, h.reactionUseCase, reviews...)
+}
+
+// decorateReviews 各レビューにリアクションの件数を設定する
+// 認証済みのリクエストの場合は、そのユーザーがいいねしているかと付けたリアクションも設定する。
+// レビューを返すハンドラーは、ルートに optionalAuthMiddleware を付けたうえでこれを呼ぶ。
+func decorateReviews(c *gin.Context, reviewUseCase interfaces.ReviewUseCase, reactionUseCase interfaces.ReactionUseCase, reviews ...*entity.SideMenuReview) error {
+	userID := viewerID(c)
+	if err := reactionUseCase.AttachReviewReactions(reviews, userID); err != nil {
+		return err
+	}
+	if userID == 0 {
+		return nil
+	}
+	return reviewUseCase.MarkLikedByUser(reviews, userID)
+}
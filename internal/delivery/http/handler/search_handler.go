@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchUseCase   interfaces.SearchUseCase
+	reviewUseCase   interfaces.ReviewUseCase
+	reactionUseCase interfaces.ReactionUseCase
+}
+
+func NewSearchHandler(searchUseCase interfaces.SearchUseCase, reviewUseCase interfaces.ReviewUseCase, reactionUseCase interfaces.ReactionUseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUseCase:   searchUseCase,
+		reviewUseCase:   reviewUseCase,
+		reactionUseCase: reactionUseCase,
+	}
+}
+
+// SearchReviews キーワードによるレビュー検索
+func (h *SearchHandler) SearchReviews(c *gin.Context) {
+	criteria, err := parseReviewCriteria(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// 検索結果は常に関連度順に並べる
+	criteria.Sort = ""
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	hits, pageInfo, err := h.searchUseCase.SearchReviews(c.Query("q"), criteria, page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	reviews := make([]*entity.SideMenuReview, 0, len(hits))
+	for _, hit := range hits {
+		reviews = append(reviews, hit.Review)
+	}
+	if err := decorateReviews(c, h.reviewUseCase, h.reactionUseCase, reviews...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": hits, "pagination": pageInfo})
+}
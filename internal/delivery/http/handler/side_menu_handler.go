@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SideMenuHandler struct {
+	sideMenuUseCase interfaces.SideMenuUseCase
+}
+
+func NewSideMenuHandler(sideMenuUseCase interfaces.SideMenuUseCase) *SideMenuHandler {
+	return &SideMenuHandler{
+		sideMenuUseCase: sideMenuUseCase,
+	}
+}
+
+// ListSideMenus サイドメニュー一覧取得
+func (h *SideMenuHandler) ListSideMenus(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	filter := entity.SideMenuFilter{
+		Category: c.Query("category"),
+		Keyword:  c.Query("q"),
+	}
+	if filter.StoreID, err = queryID(c, "store_id"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.AvailableOnly, err = queryBool(c, "available"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.listSideMenus(c, filter, page)
+}
+
+// GetSideMenusByStoreID 店舗のサイドメニュー一覧取得
+func (h *SideMenuHandler) GetSideMenusByStoreID(c *gin.Context) {
+	storeIDStr := c.Param("storeId")
+	storeID, err := strconv.ParseUint(storeIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効な店舗IDです"})
+		return
+	}
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	filter := entity.SideMenuFilter{
+		StoreID:  uint(storeID),
+		Category: c.Query("category"),
+	}
+	if filter.AvailableOnly, err = queryBool(c, "available"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.listSideMenus(c, filter, page)
+}
+
+func (h *SideMenuHandler) listSideMenus(c *gin.Context, filter entity.SideMenuFilter, page entity.PageRequest) {
+	menus, pageInfo, err := h.sideMenuUseCase.ListSideMenus(filter, page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": menus, "pagination": pageInfo})
+}
+
+// GetSideMenuByID サイドメニュー詳細取得（レビュー件数・平均評価を含む）
+func (h *SideMenuHandler) GetSideMenuByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	menu, err := h.sideMenuUseCase.GetSideMenuByID(uint(id))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": menu})
+}
+
+// CreateSideMenu サイドメニュー作成（モデレーター以上）
+func (h *SideMenuHandler) CreateSideMenu(c *gin.Context) {
+	var req entity.SideMenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	menu, err := h.sideMenuUseCase.CreateSideMenu(&req, actor)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "サイドメニューが作成されました", "data": menu})
+}
+
+// UpdateSideMenu サイドメニュー更新（モデレーター以上）
+func (h *SideMenuHandler) UpdateSideMenu(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	var req entity.SideMenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	menu, err := h.sideMenuUseCase.UpdateSideMenu(uint(id), &req, actor)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "サイドメニューが更新されました", "data": menu})
+}
+
+// DeleteSideMenu サイドメニュー削除（モデレーター以上）
+func (h *SideMenuHandler) DeleteSideMenu(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	if err := h.sideMenuUseCase.DeleteSideMenu(uint(id), actor); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "サイドメニューが削除されました"})
+}
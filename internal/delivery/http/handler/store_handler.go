@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StoreHandler struct {
+	storeUseCase    interfaces.StoreUseCase
+	reviewUseCase   interfaces.ReviewUseCase
+	reactionUseCase interfaces.ReactionUseCase
+}
+
+func NewStoreHandler(storeUseCase interfaces.StoreUseCase, reviewUseCase interfaces.ReviewUseCase, reactionUseCase interfaces.ReactionUseCase) *StoreHandler {
+	return &StoreHandler{
+		storeUseCase:    storeUseCase,
+		reviewUseCase:   reviewUseCase,
+		reactionUseCase: reactionUseCase,
+	}
+}
+
+// ListStores 店舗一覧取得
+func (h *StoreHandler) ListStores(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	stores, pageInfo, err := h.storeUseCase.ListStores(c.Query("q"), page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": stores, "pagination": pageInfo})
+}
+
+// GetStoreByID 店舗詳細取得
+func (h *StoreHandler) GetStoreByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	store, err := h.storeUseCase.GetStoreByID(uint(id))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": store})
+}
+
+// GetReviewsByStoreID 店舗のレビュー一覧取得
+func (h *StoreHandler) GetReviewsByStoreID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なページネーションパラメータです"})
+		return
+	}
+
+	reviews, pageInfo, err := h.storeUseCase.GetReviewsByStoreID(uint(id), page)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := decorateReviews(c, h.reviewUseCase, h.reactionUseCase, reviews...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reviews, "pagination": pageInfo})
+}
+
+// CreateStore 店舗作成（モデレーター以上）
+func (h *StoreHandler) CreateStore(c *gin.Context) {
+	var req entity.StoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	store, err := h.storeUseCase.CreateStore(&req, actor)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "店舗が作成されました", "data": store})
+}
+
+// UpdateStore 店舗更新（モデレーター以上）
+func (h *StoreHandler) UpdateStore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	var req entity.StoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	store, err := h.storeUseCase.UpdateStore(uint(id), &req, actor)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "店舗が更新されました", "data": store})
+}
+
+// DeleteStore 店舗削除（モデレーター以上）
+func (h *StoreHandler) DeleteStore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	if err := h.storeUseCase.DeleteStore(uint(id), actor); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "店舗が削除されました"})
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userUseCase interfaces.UserUseCase
+}
+
+func NewUserHandler(userUseCase interfaces.UserUseCase) *UserHandler {
+	return &UserHandler{
+		userUseCase: userUseCase,
+	}
+}
+
+// UpdateUserRole ユーザーのロール変更（管理者のみ）
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なユーザーIDです"})
+		return
+	}
+
+	var req entity.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	actor, exists := actorFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	user, err := h.userUseCase.UpdateUserRole(uint(id), &req, actor)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ロールを変更しました", "data": user})
+}
+
+// GetUserByHandle ハンドルからユーザーを取得
+func (h *UserHandler) GetUserByHandle(c *gin.Context) {
+	profile, err := h.userUseCase.GetUserByHandle(c.Param("handle"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profile})
+}
+
+// SearchUsers ハンドルの前方一致でユーザーを検索（メンションの入力補完用）
+func (h *UserHandler) SearchUsers(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profiles, err := h.userUseCase.SearchUsersByHandle(c.Query("q"), limit)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profiles})
+}
+
+// UpdateMyHandle 自分のハンドル変更
+func (h *UserHandler) UpdateMyHandle(c *gin.Context) {
+	var req entity.UpdateHandleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "リクエストの形式が正しくありません",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証情報が取得できません"})
+		return
+	}
+
+	user, err := h.userUseCase.UpdateHandle(userID.(uint), &req)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ハンドルを変更しました", "data": user})
+}
@@ -5,12 +5,16 @@ import (
 	"net/http"
 	"strings"
 
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // AuthMiddleware JWT認証ミドルウェア
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// 署名と有効期限に加えて、サインアウト等で失効したトークンでないかを確認する
+func AuthMiddleware(jwtSecret string, authUseCase interfaces.AuthUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Authorizationヘッダーからトークンを取得
 		authHeader := c.GetHeader("Authorization")
@@ -20,64 +24,180 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		// "Bearer "プレフィックスを除去
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証ヘッダー形式です"})
-			c.Abort()
+		if !authenticate(c, authHeader, entity.TokenTypeAccess, jwtSecret, authUseCase) {
 			return
 		}
 
-		// JWTトークンを解析
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// 署名方法を確認
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(jwtSecret), nil
-		})
+		c.Next()
+	}
+}
 
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証トークンです"})
-			c.Abort()
+// OptionalAuthMiddleware 認証が任意のルート向けのJWT認証ミドルウェア
+// Authorizationヘッダーがない場合は未認証のまま通し、ある場合は AuthMiddleware と同じく検証する。
+// 無効なトークンを未認証として扱うと、クライアントがトークンの更新に気付けないため 401 を返す。
+func OptionalAuthMiddleware(jwtSecret string, authUseCase interfaces.AuthUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+
+		if !authenticate(c, authHeader, entity.TokenTypeAccess, jwtSecret, authUseCase) {
 			return
 		}
 
-		// トークンの有効性を確認
-		if !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "認証トークンが無効です"})
+		c.Next()
+	}
+}
+
+// StreamAuthMiddleware EventSource など Authorization ヘッダーを付けられないクライアント向けのJWT認証ミドルウェア
+// ヘッダーがない場合は ticket クエリパラメータの配信用チケット（POST /auth/stream-ticket で発行）を検証する。
+// URL はアクセスログ等に残るため、クエリパラメータではアクセストークンを受け付けない。
+func StreamAuthMiddleware(jwtSecret string, authUseCase interfaces.AuthUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		tokenType := entity.TokenTypeAccess
+		if authHeader == "" {
+			if ticket := c.Query("ticket"); ticket != "" {
+				authHeader = "Bearer " + ticket
+				tokenType = entity.TokenTypeStream
+			}
+		}
+		if authHeader == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "認証トークンが提供されていません"})
 			c.Abort()
 			return
 		}
 
-		// クレームからユーザー情報を取得
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			userID, ok := claims["user_id"].(float64)
-			if !ok {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーIDが取得できません"})
-				c.Abort()
-				return
+		if !authenticate(c, authHeader, tokenType, jwtSecret, authUseCase) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate トークンを検証してユーザー情報をコンテキストに設定する
+// token_type が tokenType と異なるトークンは受け付けない。
+// 検証に失敗した場合はエラーレスポンスを書き込んで処理を中断し、false を返す。
+func authenticate(c *gin.Context, authHeader string, tokenType string, jwtSecret string, authUseCase interfaces.AuthUseCase) bool {
+	// "Bearer "プレフィックスを除去
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証ヘッダー形式です"})
+		c.Abort()
+		return false
+	}
+
+	// JWTトークンを解析
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// 署名方法を確認
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(jwtSecret), nil
+	})
+
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証トークンです"})
+		c.Abort()
+		return false
+	}
+
+	// トークンの有効性を確認
+	if !token.Valid {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証トークンが無効です"})
+		c.Abort()
+		return false
+	}
+
+	// クレームからユーザー情報を取得
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		userID, ok := claims["user_id"].(float64)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーIDが取得できません"})
+			c.Abort()
+			return false
+		}
+
+		// リフレッシュトークンや配信用チケットをアクセストークンとして使わせない
+		if claimed, _ := claims["token_type"].(string); claimed != tokenType {
+			message := "アクセストークンではありません"
+			if tokenType == entity.TokenTypeStream {
+				message = "配信用のチケットではありません"
 			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": message})
+			c.Abort()
+			return false
+		}
 
-			email, ok := claims["email"].(string)
-			if !ok {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "メールアドレスが取得できません"})
+		email, ok := claims["email"].(string)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "メールアドレスが取得できません"})
+			c.Abort()
+			return false
+		}
+
+		// ロールが含まれない場合は一般ユーザーとして扱う
+		role, _ := claims["role"].(string)
+		if role == "" {
+			role = entity.RoleUser
+		}
+
+		jti, ok := claims["jti"].(string)
+		if !ok || jti == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証トークンです"})
+			c.Abort()
+			return false
+		}
+
+		// 一括失効の世代（世代を持たないトークンは 0 として扱う）
+		var generation uint64
+		if gen, ok := claims["gen"]; ok {
+			value, ok := gen.(float64)
+			if !ok || value < 0 {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証トークンです"})
 				c.Abort()
-				return
+				return false
 			}
+			generation = uint64(value)
+		}
 
-			// デバッグログ
-			fmt.Printf("JWT認証成功 - UserID: %d, Email: %s\n", uint(userID), email)
+		expiresAt, err := claims.GetExpirationTime()
+		if err != nil || expiresAt == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "無効な認証トークンです"})
+			c.Abort()
+			return false
+		}
 
-			// コンテキストにユーザー情報を設定
-			c.Set("user_id", uint(userID))
-			c.Set("user_email", email)
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "認証トークンの解析に失敗しました"})
+		// 失効リストを確認
+		revoked, err := authUseCase.IsTokenRevoked(jti, uint(userID), generation)
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "認証状態の確認に失敗しました"})
 			c.Abort()
-			return
+			return false
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "認証トークンは失効しています"})
+			c.Abort()
+			return false
 		}
 
-		c.Next()
+		// デバッグログ
+		fmt.Printf("JWT認証成功 - UserID: %d, Email: %s\n", uint(userID), email)
+
+		// コンテキストにユーザー情報を設定
+		c.Set("user_id", uint(userID))
+		c.Set("user_email", email)
+		c.Set("user_role", role)
+		c.Set("token_id", jti)
+		c.Set("token_expires_at", expiresAt.Time)
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証トークンの解析に失敗しました"})
+		c.Abort()
+		return false
 	}
+
+	return true
 }
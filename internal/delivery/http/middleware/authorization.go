@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"sidemenulab-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole 指定したいずれかのロールを持つユーザーのみ許可する（AuthMiddleware の後に使用）
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("user_role")
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "この操作を行う権限がありません"})
+		c.Abort()
+	}
+}
+
+// RequirePermission 指定した権限を持つユーザーのみ許可する（AuthMiddleware の後に使用）
+func RequirePermission(permission entity.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !entity.RoleHasPermission(c.GetString("user_role"), permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "この操作を行う権限がありません"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamPathPrefix リアルタイム配信のパス（URL に配信用チケットが含まれる）
+const streamPathPrefix = "/api/v1/events/"
+
+// AccessLogger gin.Logger と同じ形式のアクセスログ
+// リアルタイム配信のリクエストは URL のチケットを残さないよう記録しない。
+func AccessLogger() gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Skip: func(c *gin.Context) bool {
+			return strings.HasPrefix(c.Request.URL.Path, streamPathPrefix)
+		},
+	})
+}
@@ -3,20 +3,35 @@ package http
 import (
 	"sidemenulab-backend/internal/delivery/http/handler"
 	"sidemenulab-backend/internal/delivery/http/middleware"
-	"sidemenulab-backend/internal/infrastructure/cloudinary"
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/infrastructure/realtime"
 	"sidemenulab-backend/internal/usecase/interfaces"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(r *gin.Engine, authUseCase interfaces.AuthUseCase, reviewUseCase interfaces.ReviewUseCase, reviewCommentUseCase interfaces.ReviewCommentUseCase, jwtSecret string, cloudinaryService *cloudinary.CloudinaryService) {
+func SetupRoutes(r *gin.Engine, authUseCase interfaces.AuthUseCase, passwordResetUseCase interfaces.PasswordResetUseCase, emailVerificationUseCase interfaces.EmailVerificationUseCase, userUseCase interfaces.UserUseCase, storeUseCase interfaces.StoreUseCase, sideMenuUseCase interfaces.SideMenuUseCase, reviewUseCase interfaces.ReviewUseCase, reviewCommentUseCase interfaces.ReviewCommentUseCase, reactionUseCase interfaces.ReactionUseCase, notificationUseCase interfaces.NotificationUseCase, searchUseCase interfaces.SearchUseCase, rankingUseCase interfaces.RankingUseCase, eventHub realtime.Hub, jwtSecret string, imageStorage interfaces.ImageStorage) {
 	// ハンドラーを初期化
 	authHandler := handler.NewAuthHandler(authUseCase)
-	reviewHandler := handler.NewReviewHandler(reviewUseCase, cloudinaryService)
-	reviewCommentHandler := handler.NewReviewCommentHandler(reviewCommentUseCase)
+	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetUseCase)
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationUseCase)
+	userHandler := handler.NewUserHandler(userUseCase)
+	storeHandler := handler.NewStoreHandler(storeUseCase, reviewUseCase, reactionUseCase)
+	sideMenuHandler := handler.NewSideMenuHandler(sideMenuUseCase)
+	reviewHandler := handler.NewReviewHandler(reviewUseCase, reactionUseCase, imageStorage)
+	reviewCommentHandler := handler.NewReviewCommentHandler(reviewCommentUseCase, reactionUseCase)
+	reactionHandler := handler.NewReactionHandler(reactionUseCase)
+	notificationHandler := handler.NewNotificationHandler(notificationUseCase)
+	eventHandler := handler.NewEventHandler(eventHub, reviewUseCase)
+	searchHandler := handler.NewSearchHandler(searchUseCase, reviewUseCase, reactionUseCase)
+	rankingHandler := handler.NewRankingHandler(rankingUseCase)
 
 	// 認証ミドルウェアを初期化
-	authMiddleware := middleware.AuthMiddleware(jwtSecret)
+	authMiddleware := middleware.AuthMiddleware(jwtSecret, authUseCase)
+	// 未認証でも利用でき、認証済みの場合はユーザーごとの情報（liked_by_me など）を返すルート向け
+	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(jwtSecret, authUseCase)
+	// EventSource はヘッダーを付けられないため、配信用チケットの ticket クエリパラメータでも認証する
+	streamAuthMiddleware := middleware.StreamAuthMiddleware(jwtSecret, authUseCase)
 
 	// API v1 グループ
 	v1 := r.Group("/api/v1")
@@ -26,9 +41,81 @@ func SetupRoutes(r *gin.Engine, authUseCase interfaces.AuthUseCase, reviewUseCas
 		{
 			auth.POST("/signup", authHandler.SignUp)
 			auth.POST("/signin", authHandler.SignIn)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/signout", authMiddleware, authHandler.SignOut)
+			auth.POST("/signout-all", authMiddleware, authHandler.SignOutAll)
+			auth.PUT("/password", authMiddleware, authHandler.ChangePassword)
+			auth.POST("/stream-ticket", authMiddleware, authHandler.IssueStreamTicket)
+			auth.POST("/forgot-password", passwordResetHandler.ForgotPassword)
+			auth.POST("/reset-password", passwordResetHandler.ResetPassword)
+			auth.POST("/verify-email", emailVerificationHandler.VerifyEmail)
+			auth.POST("/verify-email/resend", authMiddleware, emailVerificationHandler.ResendVerificationEmail)
 			auth.GET("/debug-token", authHandler.DebugToken)
 		}
 
+		// 管理者向けのルート
+		admin := v1.Group("/admin", authMiddleware)
+		{
+			admin.PUT("/users/:id/role", middleware.RequirePermission(entity.PermissionManageUsers), userHandler.UpdateUserRole)
+		}
+
+		// ユーザー関連のルート
+		users := v1.Group("/users")
+		{
+			users.PUT("/me/handle", authMiddleware, userHandler.UpdateMyHandle)
+			users.GET("/search", userHandler.SearchUsers)
+			users.GET("/handle/:handle", userHandler.GetUserByHandle)
+		}
+
+		// 自分へのメンション
+		v1.GET("/mentions/me", authMiddleware, reviewCommentHandler.GetMyMentions)
+
+		// リアルタイム配信（Server-Sent Events）
+		events := v1.Group("/events", streamAuthMiddleware)
+		{
+			events.GET("/reviews/:id", eventHandler.StreamReviewEvents)
+			events.GET("/notifications", eventHandler.StreamNotificationEvents)
+		}
+
+		// 通知（すべて本人のみ）
+		notifications := v1.Group("/notifications", authMiddleware)
+		{
+			notifications.GET("", notificationHandler.ListNotifications)
+			notifications.GET("/unread-count", notificationHandler.CountUnread)
+			notifications.PUT("/read-all", notificationHandler.MarkAllRead)
+			notifications.PUT("/:id/read", notificationHandler.MarkRead)
+			notifications.GET("/preferences", notificationHandler.GetPreferences)
+			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		// 店舗関連のルート
+		stores := v1.Group("/stores")
+		{
+			// 登録・編集・削除はモデレーター以上
+			manageStores := middleware.RequirePermission(entity.PermissionManageStores)
+			stores.POST("", authMiddleware, manageStores, storeHandler.CreateStore)
+			stores.PUT("/:id", authMiddleware, manageStores, storeHandler.UpdateStore)
+			stores.DELETE("/:id", authMiddleware, manageStores, storeHandler.DeleteStore)
+
+			stores.GET("", storeHandler.ListStores)
+			stores.GET("/:id", storeHandler.GetStoreByID)
+			stores.GET("/:id/reviews", optionalAuthMiddleware, storeHandler.GetReviewsByStoreID)
+		}
+
+		// サイドメニュー関連のルート
+		sideMenus := v1.Group("/side-menus")
+		{
+			// 登録・編集・削除はモデレーター以上
+			manageStores := middleware.RequirePermission(entity.PermissionManageStores)
+			sideMenus.POST("", authMiddleware, manageStores, sideMenuHandler.CreateSideMenu)
+			sideMenus.PUT("/:id", authMiddleware, manageStores, sideMenuHandler.UpdateSideMenu)
+			sideMenus.DELETE("/:id", authMiddleware, manageStores, sideMenuHandler.DeleteSideMenu)
+
+			sideMenus.GET("", sideMenuHandler.ListSideMenus)
+			sideMenus.GET("/store/:storeId", sideMenuHandler.GetSideMenusByStoreID)
+			sideMenus.GET("/:id", sideMenuHandler.GetSideMenuByID)
+		}
+
 		// レビュー関連のルート
 		reviews := v1.Group("/reviews")
 		{
@@ -39,16 +126,33 @@ func SetupRoutes(r *gin.Engine, authUseCase interfaces.AuthUseCase, reviewUseCas
 			reviews.DELETE("/:id", authMiddleware, reviewHandler.DeleteReview)
 			reviews.DELETE("/images/:imageId", authMiddleware, reviewHandler.DeleteReviewImage)
 			reviews.POST("/:id/upload-images", authMiddleware, reviewHandler.UploadReviewImages)
+			reviews.PUT("/:id/like", authMiddleware, reviewHandler.CreateReviewLike)
 			reviews.POST("/:id/like", authMiddleware, reviewHandler.CreateReviewLike)
 			reviews.DELETE("/:id/like", authMiddleware, reviewHandler.DeleteReviewLike)
+			reviews.POST("/:id/reactions", authMiddleware, reactionHandler.ToggleReviewReaction)
 			reviews.GET("/liked", authMiddleware, reviewHandler.GetLikedReviewsByUserID)
-			reviews.GET("/store/:storeName", reviewHandler.GetReviewsByStoreName)
+			reviews.GET("/trending", optionalAuthMiddleware, reviewHandler.GetTrendingReviews)
+			reviews.GET("/store/:storeName", optionalAuthMiddleware, reviewHandler.GetReviewsByStoreName)
+			reviews.GET("/side-menu/:sideMenuId", optionalAuthMiddleware, reviewHandler.GetReviewsBySideMenuID)
 			reviews.GET("/:id/images", reviewHandler.GetReviewImagesByReviewID)
 			reviews.GET("/:id/likes", reviewHandler.GetReviewLikesByReviewID)
 
 			// 認証が不要なルート（最後に定義）
-			reviews.GET("", reviewHandler.GetAllReviews)
-			reviews.GET("/:id", reviewHandler.GetReviewByID)
+			reviews.GET("", optionalAuthMiddleware, reviewHandler.GetAllReviews)
+			reviews.GET("/:id", optionalAuthMiddleware, reviewHandler.GetReviewByID)
+		}
+
+		// リアクション
+		v1.GET("/reactions", reactionHandler.ListReactionTypes)
+
+		// 検索（認証済みの場合は liked_by_me・my_reactions を返す）
+		v1.GET("/search", optionalAuthMiddleware, searchHandler.SearchReviews)
+
+		// ランキング
+		rankings := v1.Group("/rankings")
+		{
+			rankings.GET("/side-menus", rankingHandler.GetSideMenuRanking)
+			rankings.GET("/stores", rankingHandler.GetStoreRanking)
 		}
 
 		// レビューコメント関連のルート
@@ -58,12 +162,14 @@ func SetupRoutes(r *gin.Engine, authUseCase interfaces.AuthUseCase, reviewUseCas
 			reviewComments.POST("", authMiddleware, reviewCommentHandler.CreateReviewComment)
 			reviewComments.PUT("/:id", authMiddleware, reviewCommentHandler.UpdateReviewComment)
 			reviewComments.DELETE("/:id", authMiddleware, reviewCommentHandler.DeleteReviewComment)
+			reviewComments.POST("/:id/reactions", authMiddleware, reactionHandler.ToggleCommentReaction)
 
-			// 認証が不要なルート（リスト取得のみ）
-			reviewComments.GET("", reviewCommentHandler.GetAllReviewComments)
-			reviewComments.GET("/:id", reviewCommentHandler.GetReviewCommentByID)
-			reviewComments.GET("/review/:reviewId", reviewCommentHandler.GetReviewCommentsByReviewID)
-			reviewComments.GET("/user/:userId", reviewCommentHandler.GetReviewCommentsByUserID)
+			// 認証が不要なルート（リスト取得のみ、認証済みの場合は my_reactions を返す）
+			reviewComments.GET("", optionalAuthMiddleware, reviewCommentHandler.GetAllReviewComments)
+			reviewComments.GET("/:id", optionalAuthMiddleware, reviewCommentHandler.GetReviewCommentByID)
+			reviewComments.GET("/:id/replies", optionalAuthMiddleware, reviewCommentHandler.GetReplies)
+			reviewComments.GET("/review/:reviewId", optionalAuthMiddleware, reviewCommentHandler.GetReviewCommentsByReviewID)
+			reviewComments.GET("/user/:userId", optionalAuthMiddleware, reviewCommentHandler.GetReviewCommentsByUserID)
 		}
 	}
 }
@@ -6,9 +6,21 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// トークン種別（JWTの token_type クレーム）
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	// TokenTypeStream リアルタイム配信（/api/v1/events）の接続にだけ使える短命なチケット
+	TokenTypeStream = "stream"
+)
+
 type JWTClaims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
+	// TokenGeneration 発行時のユーザーの一括失効の世代（アクセストークンのみ）
+	TokenGeneration uint64 `json:"gen,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -19,10 +31,18 @@ type AuthToken struct {
 	TokenType    string    `json:"token_type"`
 }
 
+// StreamTicket EventSource の URL に付けるリアルタイム配信用のチケット
+type StreamTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type SignUpRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
 	Name     string `json:"name" binding:"required"`
+	// Handle 省略時はメールアドレスから自動で割り当てる
+	Handle string `json:"handle"`
 }
 
 type SignInRequest struct {
@@ -30,6 +50,39 @@ type SignInRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RefreshTokenRequest トークン更新リクエスト
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// SignOutRequest サインアウトリクエスト
+// refresh_token を指定した場合は、そのリフレッシュトークンのファミリーも失効させる
+type SignOutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ChangePasswordRequest パスワード変更リクエスト
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// ForgotPasswordRequest パスワードリセットメール送信リクエスト
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest パスワード再設定リクエスト
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// VerifyEmailRequest メールアドレス確認リクエスト
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 type AuthResponse struct {
 	User  *User      `json:"user"`
 	Token *AuthToken `json:"token"`
@@ -0,0 +1,45 @@
+package entity
+
+import "errors"
+
+// ユースケース層から返す共通エラー
+// ハンドラーは errors.Is で判定してHTTPステータスを決定する。
+var (
+	ErrEmailNotVerified = errors.New("メールアドレスの確認が完了していません")
+	ErrTooManyRequests  = errors.New("リクエストが多すぎます。しばらく時間をおいてから再度お試しください")
+	ErrNotFound         = errors.New("リソースが見つかりません")
+	ErrForbidden        = errors.New("この操作を行う権限がありません")
+	ErrInvalidCriteria  = errors.New("検索条件が正しくありません")
+	ErrConflict         = errors.New("既に登録されています")
+	ErrInvalidRequest   = errors.New("リクエストの内容が正しくありません")
+	ErrInvalidImage     = errors.New("画像ファイルが正しくありません")
+)
+
+// NotFoundError 対象のリソースが存在しない
+type NotFoundError struct {
+	Resource string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Resource + "が見つかりません"
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ForbiddenError リソースに対する操作権限がない
+type ForbiddenError struct {
+	Resource   string
+	ResourceID uint
+	Action     string
+	UserID     uint
+}
+
+func (e *ForbiddenError) Error() string {
+	return "この" + e.Resource + e.Action + "する権限がありません"
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
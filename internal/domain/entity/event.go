@@ -0,0 +1,43 @@
+package entity
+
+import "fmt"
+
+// リアルタイム配信のイベントの種類
+const (
+	EventReviewUpdated       = "review.updated"
+	EventReviewDeleted       = "review.deleted"
+	EventReviewLiked         = "review.liked"
+	EventReviewUnliked       = "review.unliked"
+	EventCommentCreated      = "comment.created"
+	EventCommentUpdated      = "comment.updated"
+	EventCommentDeleted      = "comment.deleted"
+	EventNotificationCreated = "notification.created"
+)
+
+// Event 購読者に配信するイベント（Data は JSON として送信する）
+type Event struct {
+	Type string
+	Data interface{}
+}
+
+// ReviewLikeData review.liked / review.unliked のデータ
+type ReviewLikeData struct {
+	ReviewID uint `json:"review_id"`
+	UserID   uint `json:"user_id"`
+}
+
+// DeletedData review.deleted / comment.deleted のデータ
+type DeletedData struct {
+	ID       uint `json:"id"`
+	ReviewID uint `json:"review_id"`
+}
+
+// ReviewTopic レビューへのコメント・いいね・更新を配信するトピック
+func ReviewTopic(reviewID uint) string {
+	return fmt.Sprintf("review:%d", reviewID)
+}
+
+// UserNotificationTopic ユーザー宛ての通知を配信するトピック
+func UserNotificationTopic(userID uint) string {
+	return fmt.Sprintf("user:%d:notifications", userID)
+}
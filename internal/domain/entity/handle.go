@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	MinHandleLength = 3
+	MaxHandleLength = 30
+)
+
+var handlePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// UserProfile 他のユーザーに公開するユーザー情報（メールアドレスは含まない）
+type UserProfile struct {
+	ID     uint   `json:"id"`
+	Handle string `json:"handle"`
+	Name   string `json:"name"`
+}
+
+// UpdateHandleRequest ハンドル変更リクエスト
+type UpdateHandleRequest struct {
+	Handle string `json:"handle" binding:"required"`
+}
+
+// NormalizeHandle 先頭の @ と前後の空白を取り除き、小文字にそろえる
+func NormalizeHandle(handle string) string {
+	handle = strings.TrimSpace(handle)
+	handle = strings.TrimPrefix(strings.TrimPrefix(handle, "@"), "＠")
+	return strings.ToLower(handle)
+}
+
+// ValidateHandle 正規化済みのハンドルが使用できる形式か確認する
+func ValidateHandle(handle string) error {
+	if len(handle) < MinHandleLength || len(handle) > MaxHandleLength || !handlePattern.MatchString(handle) {
+		return fmt.Errorf("%w: ハンドルは半角英数字とアンダースコアの%d〜%d文字で指定してください", ErrInvalidRequest, MinHandleLength, MaxHandleLength)
+	}
+	return nil
+}
+
+// ValidateHandlePrefix ハンドルの前方一致検索に使う正規化済みの文字列を確認する
+// ハンドルに使えない文字（LIKE のワイルドカードの % など）を含む場合は検索させない。
+func ValidateHandlePrefix(prefix string) error {
+	if len(prefix) > MaxHandleLength || !handlePattern.MatchString(prefix) {
+		return fmt.Errorf("%w: q は半角英数字とアンダースコアの%d文字以内で指定してください", ErrInvalidCriteria, MaxHandleLength)
+	}
+	return nil
+}
+
+// HandleBaseFromEmail メールアドレスのローカル部からハンドルの候補を作る
+// 使えない文字はアンダースコアに置き換え、重複時に番号を付けられるよう短めに切り詰める。
+func HandleBaseFromEmail(email string) string {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+
+	var b strings.Builder
+	for _, r := range local {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+
+	base := strings.Trim(b.String(), "_")
+	if len(base) > MaxHandleLength-5 {
+		base = base[:MaxHandleLength-5]
+	}
+	if len(base) < MinHandleLength {
+		base = "user_" + base
+	}
+	return base
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateHandlePrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		valid  bool
+	}{
+		{"英数字", "tana", true},
+		{"アンダースコア", "tanaka_", true},
+		{"1文字", "t", true},
+		{"最大長", "abcdefghijklmnopqrstuvwxyz0123", true},
+		{"最大長を超える", "abcdefghijklmnopqrstuvwxyz01234", false},
+		{"LIKE のワイルドカード", "ta%", false},
+		{"バックスラッシュ", `ta\`, false},
+		{"大文字（正規化前）", "Tana", false},
+		{"全角文字", "たなか", false},
+		{"空白", "ta na", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHandlePrefix(tt.prefix)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateHandlePrefix(%q) = %v, エラーにならないはず", tt.prefix, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidCriteria) {
+				t.Errorf("ValidateHandlePrefix(%q) = %v, ErrInvalidCriteria になるはず", tt.prefix, err)
+			}
+		})
+	}
+}
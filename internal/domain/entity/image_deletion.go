@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// MaxImageDeletionAttempts 保存先からの削除を再試行する上限回数（超えたジョブは残したまま処理しない）
+const MaxImageDeletionAttempts = 10
+
+// ImageDeletionJob 保存先から削除する画像
+// 画像の行を削除するのと同じトランザクションで登録し、定期ジョブが保存先から削除する。
+// 削除に失敗した場合は NextAttemptAt を延ばして再試行する。
+type ImageDeletionJob struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	StorageProvider string    `gorm:"size:20;not null;index:idx_image_deletion_jobs_due,priority:1" json:"storage_provider"`
+	StorageKey      string    `gorm:"size:500;not null" json:"storage_key"`
+	Attempts        int       `gorm:"not null" json:"attempts"`
+	NextAttemptAt   time.Time `gorm:"not null;index:idx_image_deletion_jobs_due,priority:2" json:"next_attempt_at"`
+	LastError       string    `gorm:"size:1000" json:"last_error,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// NewImageDeletionJobs 保存先に実体のある画像について削除ジョブを作る
+func NewImageDeletionJobs(images []*SideMenuReviewImage, now time.Time) []*ImageDeletionJob {
+	var jobs []*ImageDeletionJob
+	for _, image := range images {
+		if image.StorageProvider == "" || image.StorageKey == "" {
+			continue
+		}
+		jobs = append(jobs, &ImageDeletionJob{
+			StorageProvider: image.StorageProvider,
+			StorageKey:      image.StorageKey,
+			NextAttemptAt:   now,
+		})
+	}
+	return jobs
+}
+
+// Fail 失敗を記録し、次の試行を指数的に遅らせる（1分から始めて最大6時間）
+func (j *ImageDeletionJob) Fail(err error, now time.Time) {
+	j.Attempts++
+	j.LastError = err.Error()
+	if message := []rune(j.LastError); len(message) > 1000 {
+		j.LastError = string(message[:1000])
+	}
+
+	backoff := time.Minute << min(j.Attempts-1, 9)
+	if backoff > 6*time.Hour {
+		backoff = 6 * time.Hour
+	}
+	j.NextAttemptAt = now.Add(backoff)
+}
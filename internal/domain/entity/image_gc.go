@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// ImageGCOptions 保存先に残った不要な画像の回収方法
+type ImageGCOptions struct {
+	// DryRun true の場合は削除せずに報告のみ行う
+	DryRun bool
+	// GracePeriod アップロードからこの時間が経過していない画像は対象にしない
+	// （保存先へのアップロードから画像の行の登録までの間に回収しないため）
+	GracePeriod time.Duration
+}
+
+// DefaultImageGCOptions 既定の回収方法（24時間より古い画像を報告のみ行う）
+// 回収の判定を誤ると画像を失うため、削除は明示的に DryRun を false にした場合だけ行う。
+func DefaultImageGCOptions() ImageGCOptions {
+	return ImageGCOptions{DryRun: true, GracePeriod: 24 * time.Hour}
+}
+
+// OrphanedImage どの画像の行からも参照されていない保存先の画像
+type OrphanedImage struct {
+	Key       string    `json:"key"`
+	Bytes     int       `json:"bytes"`
+	CreatedAt time.Time `json:"created_at"`
+	Deleted   bool      `json:"deleted"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ImageGCReport 回収の結果
+type ImageGCReport struct {
+	Provider string `json:"provider"`
+	Prefix   string `json:"prefix"`
+	DryRun   bool   `json:"dry_run"`
+	// Scanned 保存先にあった画像の数
+	Scanned int `json:"scanned"`
+	// Recent 猶予期間内のため対象外とした画像の数
+	Recent int `json:"recent"`
+	// Referenced 画像の行から参照されている画像の数
+	Referenced int `json:"referenced"`
+	// Queued 削除ジョブに登録済みのため対象外とした画像の数
+	Queued  int              `json:"queued"`
+	Orphans []*OrphanedImage `json:"orphans"`
+	Deleted int              `json:"deleted"`
+	Failed  int              `json:"failed"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+// 画像の保存先の種類（SideMenuReviewImage・ImageDeletionJob の StorageProvider に記録する）
+const (
+	StorageProviderCloudinary = "cloudinary"
+	StorageProviderLocal      = "local"
+)
+
+// アップロードを受け付けるレビュー画像の上限
+// 圧縮された画像は小さなファイルでも展開すると巨大になるため、ファイルサイズとは別に幅・高さと画素数を制限する。
+const (
+	MaxReviewImageBytes     = 5 * 1024 * 1024
+	MaxReviewImageDimension = 8192
+	MaxReviewImagePixels    = 40 * 1000 * 1000
+	// MaxReviewImagesPerUpload 1回のアップロードで受け付けるファイル数
+	MaxReviewImagesPerUpload = 10
+)
+
+// InvalidImageFile 検証に失敗したアップロードファイル
+type InvalidImageFile struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,8 @@
+package entity
+
+// MailMessage 送信するメール
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
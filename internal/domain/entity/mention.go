@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxMentionsPerComment 1件のコメントでメンションできるユーザー数の上限
+const MaxMentionsPerComment = 10
+
+// mentionPattern 英数字の直後ではない @handle（全角の ＠ も可）
+// メールアドレスのような表記をメンションとして扱わないよう、直前の文字も含めて照合する。
+var mentionPattern = regexp.MustCompile(`(^|[^A-Za-z0-9_@＠])[@＠]([A-Za-z0-9_]+)`)
+
+// CommentMention コメント内の @handle が指すユーザー
+type CommentMention struct {
+	ID        uint `gorm:"primaryKey" json:"-"`
+	CommentID uint `gorm:"not null;uniqueIndex:idx_comment_mentions_comment_user,priority:1" json:"-"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_comment_mentions_comment_user,priority:2;index" json:"user_id"`
+	// Handle コメントに書かれた時点のハンドル（後でユーザーがハンドルを変えても本文と一致させるため）
+	Handle    string    `gorm:"size:30;not null" json:"handle"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// ParseMentionHandles 本文中の @handle を出現順・重複なしで、正規化して返す
+// 形式として正しくないハンドルは含めない（実在するかどうかは呼び出し側で確認する）。
+func ParseMentionHandles(text string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(match[2])
+		if seen[handle] || ValidateHandle(handle) != nil {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
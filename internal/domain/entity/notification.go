@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationType 通知の種類
+type NotificationType string
+
+const (
+	NotificationReviewLiked     NotificationType = "review_liked"
+	NotificationReviewCommented NotificationType = "review_commented"
+	NotificationCommentReplied  NotificationType = "comment_replied"
+	NotificationMentioned       NotificationType = "mentioned"
+	// NotificationModeration 自分の投稿が他のユーザー（モデレーターなど）に編集・削除された
+	NotificationModeration NotificationType = "moderation"
+)
+
+// ReviewLikedNotificationWindow いいねを取り消して付け直しても、同じユーザーから同じレビューへのいいねを改めて通知しない期間
+const ReviewLikedNotificationWindow = 24 * time.Hour
+
+// notificationTypes 通知の種類（設定の表示順）
+var notificationTypes = []NotificationType{
+	NotificationReviewLiked,
+	NotificationReviewCommented,
+	NotificationCommentReplied,
+	NotificationMentioned,
+	NotificationModeration,
+}
+
+// Notification ユーザーへのアプリ内通知
+type Notification struct {
+	ID     uint             `gorm:"primaryKey;index:idx_notifications_user_created_id,priority:3" json:"id"`
+	UserID uint             `gorm:"not null;index:idx_notifications_user_created_id,priority:1" json:"user_id"`
+	Type   NotificationType `gorm:"size:30;not null" json:"type"`
+	// ActorID 通知のきっかけになった操作をしたユーザー
+	ActorID   *uint      `json:"actor_id"`
+	Actor     *User      `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	ReviewID  *uint      `json:"review_id"`
+	CommentID *uint      `json:"comment_id"`
+	Message   string     `gorm:"not null" json:"message"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `gorm:"index:idx_notifications_user_created_id,priority:2" json:"created_at"`
+}
+
+// DedupWindow 同じユーザーから同じ対象への同じ種類の通知をまとめる期間（0 の場合はまとめない）
+func (t NotificationType) DedupWindow() time.Duration {
+	if t == NotificationReviewLiked {
+		return ReviewLikedNotificationWindow
+	}
+	return 0
+}
+
+// NotificationPreference 通知の種類ごとの受信設定（行がない種類は受信する）
+type NotificationPreference struct {
+	UserID    uint             `gorm:"primaryKey" json:"-"`
+	Type      NotificationType `gorm:"primaryKey;size:30" json:"type"`
+	Enabled   bool             `gorm:"not null" json:"enabled"`
+	UpdatedAt time.Time        `json:"-"`
+}
+
+// NotificationPreferences 通知の種類ごとに受信するかどうか
+type NotificationPreferences map[NotificationType]bool
+
+// DefaultNotificationPreferences すべての種類を受信する設定
+func DefaultNotificationPreferences() NotificationPreferences {
+	prefs := make(NotificationPreferences, len(notificationTypes))
+	for _, t := range notificationTypes {
+		prefs[t] = true
+	}
+	return prefs
+}
+
+// Validate 未定義の種類が含まれていないか確認する
+func (p NotificationPreferences) Validate() error {
+	for t := range p {
+		if !IsValidNotificationType(t) {
+			return fmt.Errorf("%w: 通知の種類 %s はサポートされていません", ErrInvalidRequest, t)
+		}
+	}
+	return nil
+}
+
+// IsValidNotificationType 定義済みの通知の種類かどうか
+func IsValidNotificationType(t NotificationType) bool {
+	for _, known := range notificationTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
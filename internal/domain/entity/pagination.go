@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// 一覧取得の件数
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// ErrInvalidCursor カーソルの形式が正しくない
+var ErrInvalidCursor = errors.New("無効なカーソルです")
+
+// Cursor キーセットページネーションの位置（created_at, id の組）
+// クライアントには Encode した不透明な文字列として渡す。
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"i"`
+	// Sort と Value は created_at 以外で並べ替えた一覧で使う（並び順の名前とその値）
+	Sort  string `json:"s,omitempty"`
+	Value int64  `json:"v,omitempty"`
+	// Generation 並び順 trending で、並べ替えに使ったトレンドスコアの世代
+	Generation uint64 `json:"g,omitempty"`
+	// Backward が true の場合は、この位置より前（新しい側）のページを指す
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode カーソルを不透明な文字列に変換する
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor Encode した文字列からカーソルを復元する
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// PageRequest 一覧取得のページ指定
+// Cursor が nil の場合は先頭（最新）のページを返す。
+type PageRequest struct {
+	Cursor *Cursor
+	Limit  int
+}
+
+// NewPageRequest 件数を既定値・上限に丸めたページ指定を作成する
+func NewPageRequest(cursor *Cursor, limit int) PageRequest {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	return PageRequest{Cursor: cursor, Limit: limit}
+}
+
+// PageInfo 一覧レスポンスのページ情報
+type PageInfo struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	// HasMore 要求した方向にさらにデータがあるかどうか
+	HasMore bool `json:"has_more"`
+}
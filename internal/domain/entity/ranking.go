@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// ランキングの件数
+const (
+	DefaultRankingLimit = 20
+	// MaxRankingLimit ランキングとして計算・保持する最大件数
+	MaxRankingLimit = 50
+)
+
+// RankingParams ランキングの計算条件
+type RankingParams struct {
+	// Category サイドメニューのカテゴリ（空の場合はすべてのカテゴリ）
+	Category string
+	// MinReviews ランキングに載せるのに必要なレビュー件数
+	MinReviews int
+	// PriorMean ベイズ平均の事前平均（全体の平均評価）
+	PriorMean float64
+	Limit     int
+}
+
+// RankingScore ランキングの順位付けに使う値
+// BayesianRating の高い順に並べ、同じ場合はいいね数の多い順とする。
+type RankingScore struct {
+	ReviewCount    int64   `json:"review_count"`
+	AverageRating  float64 `json:"average_rating"`
+	BayesianRating float64 `json:"bayesian_rating"`
+	LikeCount      int64   `json:"like_count"`
+}
+
+// SideMenuRanking サイドメニューランキングの1件
+type SideMenuRanking struct {
+	Rank     int       `json:"rank"`
+	SideMenu *SideMenu `json:"side_menu"`
+	RankingScore
+}
+
+// StoreRanking 店舗ランキングの1件
+type StoreRanking struct {
+	Rank  int    `json:"rank"`
+	Store *Store `json:"store"`
+	RankingScore
+}
+
+// Rankings 定期的に再計算したランキング
+// キーはカテゴリ（全カテゴリは空文字列）。
+type Rankings struct {
+	SideMenus   map[string][]*SideMenuRanking
+	Stores      map[string][]*StoreRanking
+	MinReviews  int
+	GeneratedAt time.Time
+}
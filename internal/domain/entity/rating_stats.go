@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// 評価の集計対象
+const (
+	RatingSubjectOverall  = "overall"
+	RatingSubjectStore    = "store"
+	RatingSubjectSideMenu = "side_menu"
+)
+
+// RatingPriorWeight ベイズ平均で全体平均に寄せる重み（仮想的なレビュー件数）
+// レビューが少ない対象ほど全体平均に近い値になり、1件の★5が上位を占めることを防ぐ。
+const RatingPriorWeight = 10
+
+// RatingStats 対象ごとのレビュー評価の集計
+// レビューの作成・更新・削除と同じトランザクションで差分を反映する。
+// 全体（RatingSubjectOverall）の集計は SubjectID を 0 として保持する。
+type RatingStats struct {
+	SubjectType string    `gorm:"primaryKey;size:20" json:"-"`
+	SubjectID   uint      `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	ReviewCount int64     `gorm:"not null" json:"review_count"`
+	RatingSum   int64     `gorm:"not null" json:"-"`
+	Star1       int64     `gorm:"not null" json:"-"`
+	Star2       int64     `gorm:"not null" json:"-"`
+	Star3       int64     `gorm:"not null" json:"-"`
+	Star4       int64     `gorm:"not null" json:"-"`
+	Star5       int64     `gorm:"not null" json:"-"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Average 平均評価（レビューがない場合は 0）
+func (s *RatingStats) Average() float64 {
+	if s.ReviewCount <= 0 {
+		return 0
+	}
+	return float64(s.RatingSum) / float64(s.ReviewCount)
+}
+
+// BayesianAverage 全体平均を事前分布としたベイズ平均
+// 全体にレビューがない場合は評価の中央値（3）を事前平均とする。
+func (s *RatingStats) BayesianAverage(overall *RatingStats) float64 {
+	prior := 3.0
+	if overall != nil && overall.ReviewCount > 0 {
+		prior = overall.Average()
+	}
+	return (RatingPriorWeight*prior + float64(s.RatingSum)) / (RatingPriorWeight + float64(s.ReviewCount))
+}
+
+// Summary レスポンス用の集計値を作る
+func (s *RatingStats) Summary(overall *RatingStats) RatingSummary {
+	stars := []int64{s.Star1, s.Star2, s.Star3, s.Star4, s.Star5}
+	histogram := make(map[string]int64, len(stars))
+	for i, count := range stars {
+		histogram[strconv.Itoa(i+1)] = count
+	}
+	return RatingSummary{
+		ReviewCount:    s.ReviewCount,
+		AverageRating:  s.Average(),
+		BayesianRating: s.BayesianAverage(overall),
+		Histogram:      histogram,
+	}
+}
+
+// RatingSummary 評価の集計値
+type RatingSummary struct {
+	ReviewCount   int64   `json:"review_count"`
+	AverageRating float64 `json:"average_rating"`
+	// BayesianRating レビュー件数の少なさを補正した評価（ランキング用）
+	BayesianRating float64 `json:"bayesian_rating"`
+	// Histogram ★1〜★5 ごとのレビュー件数（キーは "1"〜"5"）
+	Histogram map[string]int64 `json:"histogram"`
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+// statsOf 評価の一覧から集計を作る
+func statsOf(ratings ...int) *RatingStats {
+	stats := &RatingStats{}
+	stars := []*int64{&stats.Star1, &stats.Star2, &stats.Star3, &stats.Star4, &stats.Star5}
+	for _, rating := range ratings {
+		stats.ReviewCount++
+		stats.RatingSum += int64(rating)
+		*stars[rating-1]++
+	}
+	return stats
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRatingStatsAverage(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats *RatingStats
+		want  float64
+	}{
+		{name: "レビューなし", stats: statsOf(), want: 0},
+		{name: "1件", stats: statsOf(4), want: 4},
+		{name: "複数件", stats: statsOf(5, 4, 3, 3), want: 3.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.Average(); !almostEqual(got, tt.want) {
+				t.Errorf("Average() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingStatsBayesianAverage(t *testing.T) {
+	// 全体平均 3.5（★3 と ★4 が同数）
+	overall := statsOf(3, 4, 3, 4)
+
+	tests := []struct {
+		name    string
+		stats   *RatingStats
+		overall *RatingStats
+		want    float64
+	}{
+		{name: "レビューなしは全体平均", stats: statsOf(), overall: overall, want: 3.5},
+		{name: "全体にもレビューがなければ中央値", stats: statsOf(), overall: statsOf(), want: 3},
+		{name: "全体の集計がなければ中央値", stats: statsOf(), overall: nil, want: 3},
+		{name: "★5が1件", stats: statsOf(5), overall: overall, want: (10*3.5 + 5) / 11},
+		{name: "★5が10件", stats: statsOf(5, 5, 5, 5, 5, 5, 5, 5, 5, 5), overall: overall, want: (10*3.5 + 50) / 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.BayesianAverage(tt.overall); !almostEqual(got, tt.want) {
+				t.Errorf("BayesianAverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingStatsBayesianAverageOrdering(t *testing.T) {
+	overall := statsOf(3, 4, 3, 4)
+	single := statsOf(5)
+	many := statsOf(5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 4, 4)
+
+	// 平均は ★5 が1件の方が高いが、件数の多い方を上位にする
+	if single.Average() <= many.Average() {
+		t.Fatal("前提: ★5が1件の方が平均評価が高い")
+	}
+	if single.BayesianAverage(overall) >= many.BayesianAverage(overall) {
+		t.Errorf("BayesianAverage: ★5が1件 = %v, 多数の高評価 = %v, 多数の高評価の方が高くなるべき",
+			single.BayesianAverage(overall), many.BayesianAverage(overall))
+	}
+}
+
+func TestRatingStatsSummary(t *testing.T) {
+	summary := statsOf(5, 5, 4, 1).Summary(statsOf(3))
+
+	if summary.ReviewCount != 4 {
+		t.Errorf("ReviewCount = %d, want 4", summary.ReviewCount)
+	}
+	if !almostEqual(summary.AverageRating, 3.75) {
+		t.Errorf("AverageRating = %v, want 3.75", summary.AverageRating)
+	}
+	if want := (10*3.0 + 15) / 14; !almostEqual(summary.BayesianRating, want) {
+		t.Errorf("BayesianRating = %v, want %v", summary.BayesianRating, want)
+	}
+
+	wantHistogram := map[string]int64{"1": 1, "2": 0, "3": 0, "4": 1, "5": 2}
+	if len(summary.Histogram) != len(wantHistogram) {
+		t.Errorf("Histogram = %v, want %v", summary.Histogram, wantHistogram)
+	}
+	for star, want := range wantHistogram {
+		if got, ok := summary.Histogram[star]; !ok || got != want {
+			t.Errorf("Histogram[%s] = %d, want %d", star, got, want)
+		}
+	}
+}
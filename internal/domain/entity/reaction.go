@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReactionTargetType リアクションを付ける対象の種類
+type ReactionTargetType string
+
+const (
+	ReactionTargetReview  ReactionTargetType = "review"
+	ReactionTargetComment ReactionTargetType = "comment"
+)
+
+// ReactionType リアクションの種類
+type ReactionType string
+
+const (
+	ReactionDelicious  ReactionType = "delicious"
+	ReactionWillRepeat ReactionType = "will_repeat"
+	ReactionMeh        ReactionType = "meh"
+	ReactionGreatValue ReactionType = "great_value"
+	ReactionWantToTry  ReactionType = "want_to_try"
+)
+
+// ReactionTypeInfo リアクションの種類と表示名
+type ReactionTypeInfo struct {
+	Type  ReactionType `json:"type"`
+	Label string       `json:"label"`
+	Emoji string       `json:"emoji"`
+}
+
+// reactionTypes 利用できるリアクション（表示順）
+var reactionTypes = []ReactionTypeInfo{
+	{Type: ReactionDelicious, Label: "美味しい", Emoji: "😋"},
+	{Type: ReactionWillRepeat, Label: "リピート確定", Emoji: "🔁"},
+	{Type: ReactionMeh, Label: "微妙", Emoji: "🤔"},
+	{Type: ReactionGreatValue, Label: "コスパ最高", Emoji: "💰"},
+	{Type: ReactionWantToTry, Label: "食べてみたい", Emoji: "👀"},
+}
+
+// ReactionTypes 利用できるリアクションを表示順に返す
+func ReactionTypes() []ReactionTypeInfo {
+	types := make([]ReactionTypeInfo, len(reactionTypes))
+	copy(types, reactionTypes)
+	return types
+}
+
+// ValidateReactionType 定義済みのリアクションかどうか確認する
+func ValidateReactionType(reactionType ReactionType) error {
+	for _, info := range reactionTypes {
+		if info.Type == reactionType {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: リアクション %s はサポートされていません", ErrInvalidRequest, reactionType)
+}
+
+// Reaction レビュー・コメントに付けたリアクション
+// 同じユーザーが同じ対象に同じ種類のリアクションを付けられるのは1件まで。
+type Reaction struct {
+	ID         uint               `gorm:"primaryKey" json:"id"`
+	TargetType ReactionTargetType `gorm:"size:20;not null;uniqueIndex:idx_reactions_target_user_type,priority:1" json:"target_type"`
+	TargetID   uint               `gorm:"not null;uniqueIndex:idx_reactions_target_user_type,priority:2" json:"target_id"`
+	UserID     uint               `gorm:"not null;uniqueIndex:idx_reactions_target_user_type,priority:3;index" json:"user_id"`
+	Type       ReactionType       `gorm:"size:30;not null;uniqueIndex:idx_reactions_target_user_type,priority:4" json:"type"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// ReactionCounts リアクションの種類ごとの件数（0件の種類は含まない）
+type ReactionCounts map[ReactionType]int64
+
+// ToggleReactionRequest リアクションの切り替えリクエスト
+type ToggleReactionRequest struct {
+	Type ReactionType `json:"type" binding:"required"`
+}
+
+// ReactionToggleResult リアクションを切り替えた後の対象の状態
+type ReactionToggleResult struct {
+	Type ReactionType `json:"type"`
+	// Reacted 切り替えの結果リアクションが付いた状態なら true
+	Reacted     bool           `json:"reacted"`
+	Reactions   ReactionCounts `json:"reactions"`
+	MyReactions []ReactionType `json:"my_reactions"`
+}
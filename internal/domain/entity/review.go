@@ -6,18 +6,37 @@ import (
 	"gorm.io/gorm"
 )
 
+// インデックス idx_side_menu_reviews_*_created はキーセットページング（created_at, id の降順）用
 type SideMenuReview struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
+	ID           uint           `gorm:"primaryKey;index:idx_side_menu_reviews_created,priority:2;index:idx_side_menu_reviews_user_created,priority:3;index:idx_side_menu_reviews_store_created,priority:3;index:idx_side_menu_reviews_side_menu_created,priority:3" json:"id"`
+	StoreID      *uint          `gorm:"index:idx_side_menu_reviews_store_created,priority:1" json:"store_id"`
+	Store        *Store         `gorm:"foreignKey:StoreID;constraint:OnDelete:SET NULL" json:"store,omitempty"`
+	// StoreName 旧クライアント向けに残している店舗名（店舗に紐付いている場合は店舗の正式名）
 	StoreName    string         `gorm:"not null" json:"store_name"`
+	SideMenuID   *uint          `gorm:"index:idx_side_menu_reviews_side_menu_created,priority:1" json:"side_menu_id"`
+	SideMenu     *SideMenu      `gorm:"foreignKey:SideMenuID;constraint:OnDelete:SET NULL" json:"side_menu,omitempty"`
+	// SideMenuName 旧クライアント向けに残しているメニュー名（サイドメニューに紐付いている場合はその名前）
 	SideMenuName string         `gorm:"not null" json:"side_menu_name"`
-	UserID       uint           `gorm:"not null" json:"user_id"`
+	UserID       uint           `gorm:"not null;index:idx_side_menu_reviews_user_created,priority:1" json:"user_id"`
 	User         User           `gorm:"foreignKey:UserID" json:"user"`
 	Rating       int            `gorm:"not null;check:rating >= 1 AND rating <= 5" json:"rating"`
 	Title        string         `json:"title"`
 	Comment      string         `json:"comment"`
 	IsVerified   bool           `gorm:"default:false" json:"is_verified"`
 	Images       []SideMenuReviewImage `gorm:"foreignKey:ReviewID" json:"images"`
-	CreatedAt    time.Time      `json:"created_at"`
+	// LikeCount いいね数（いいねの登録・取り消しと同じトランザクションで更新する）
+	LikeCount    int64          `gorm:"not null;default:0" json:"like_count"`
+	// LikedByMe 認証済みのリクエストで、そのユーザーがいいねしているかどうか
+	LikedByMe    bool           `gorm:"-" json:"liked_by_me"`
+	// Reactions リアクションの種類ごとの件数
+	Reactions    ReactionCounts `gorm:"-" json:"reactions"`
+	// MyReactions 認証済みのリクエストで、そのユーザーが付けたリアクション
+	MyReactions  []ReactionType `gorm:"-" json:"my_reactions,omitempty"`
+	SearchKey    string         `gorm:"type:text;not null;default:''" json:"-"`
+	SearchText   string         `gorm:"type:text;not null;default:''" json:"-"`
+	// TrendingScore 保存用のトレンドスコア（TrendingPolicy.Score、投稿時に設定し定期ジョブが更新する）
+	TrendingScore int64         `gorm:"not null;default:0;index" json:"-"`
+	CreatedAt    time.Time      `gorm:"index:idx_side_menu_reviews_created,priority:1;index:idx_side_menu_reviews_user_created,priority:2;index:idx_side_menu_reviews_store_created,priority:2;index:idx_side_menu_reviews_side_menu_created,priority:2" json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
@@ -28,21 +47,34 @@ type SideMenuReviewImage struct {
 	Review      SideMenuReview `gorm:"foreignKey:ReviewID" json:"review"`
 	ImageURL    string    `gorm:"not null" json:"image_url"`
 	ImageOrder  int       `gorm:"default:0" json:"image_order"`
+	// StorageProvider, StorageKey アップロードした画像の保存先（外部URLを登録した画像では空）
+	StorageProvider string `gorm:"size:20" json:"storage_provider,omitempty"`
+	StorageKey  string    `gorm:"size:500;index" json:"-"`
+	Width       int       `json:"width,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	Format      string    `gorm:"size:20" json:"format,omitempty"`
+	Bytes       int       `json:"bytes,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// SideMenuReviewLike レビューへのいいね（1ユーザーにつき1レビュー1件）
+// インデックス idx_side_menu_review_likes_*_created はキーセットページング（created_at, id の降順）用
 type SideMenuReviewLike struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	ReviewID  uint      `gorm:"not null" json:"review_id"`
+	ID        uint      `gorm:"primaryKey;index:idx_side_menu_review_likes_review_created,priority:3;index:idx_side_menu_review_likes_user_created,priority:3" json:"id"`
+	ReviewID  uint      `gorm:"not null;uniqueIndex:idx_side_menu_review_likes_review_user;index:idx_side_menu_review_likes_review_created,priority:1" json:"review_id"`
 	Review    SideMenuReview `gorm:"foreignKey:ReviewID" json:"review"`
-	UserID    uint      `gorm:"not null" json:"user_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_side_menu_review_likes_review_user;index:idx_side_menu_review_likes_user_created,priority:1" json:"user_id"`
 	User      User      `gorm:"foreignKey:UserID" json:"user"`
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time `gorm:"index:idx_side_menu_review_likes_review_created,priority:2;index:idx_side_menu_review_likes_user_created,priority:2" json:"created_at"`
 }
 
 type CreateReviewRequest struct {
-	StoreName    string `json:"store_name" binding:"required"`
-	SideMenuName string `json:"side_menu_name" binding:"required"`
+	// SideMenuID を指定しない場合は、店舗（StoreID または StoreName）と SideMenuName を指定する
+	// StoreName は別名でもよい
+	SideMenuID   *uint  `json:"side_menu_id"`
+	StoreID      *uint  `json:"store_id"`
+	StoreName    string `json:"store_name" binding:"required_without_all=StoreID SideMenuID"`
+	SideMenuName string `json:"side_menu_name" binding:"required_without=SideMenuID"`
 	Rating       int    `json:"rating" binding:"required,min=1,max=5"`
 	Title        string `json:"title"`
 	Comment      string `json:"comment"`
@@ -52,4 +84,11 @@ type CreateReviewImageRequest struct {
 	ReviewID   uint   `json:"review_id" binding:"required"`
 	ImageURL   string `json:"image_url" binding:"required"`
 	ImageOrder int    `json:"image_order"`
+	// 以下はアップロード時にサーバーが設定する（リクエストボディからは受け付けない）
+	StorageProvider string `json:"-"`
+	StorageKey string `json:"-"`
+	Width      int    `json:"-"`
+	Height     int    `json:"-"`
+	Format     string `json:"-"`
+	Bytes      int    `json:"-"`
 }
@@ -1,26 +1,134 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// MaxReviewCommentDepth 返信を重ねられる深さの上限（トップレベルのコメントは 0）
+const MaxReviewCommentDepth = 3
+
+// DeletedReviewCommentText 返信が付いたまま削除されたコメントに表示する本文
+const DeletedReviewCommentText = "このコメントは削除されました"
+
 // ReviewComment レビューコメントエンティティ
+// インデックス idx_review_comments_*_created はキーセットページング（created_at, id の降順）用
 type ReviewComment struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	ReviewID  uint           `gorm:"not null" json:"review_id"`
-	Review    SideMenuReview `gorm:"foreignKey:ReviewID" json:"review"`
-	UserID    uint           `gorm:"not null" json:"user_id"`
-	User      User           `gorm:"foreignKey:UserID" json:"user"`
-	Comment   string         `gorm:"not null" json:"comment"`
-	CreatedAt time.Time      `json:"created_at"`
+	ID       uint           `gorm:"primaryKey;index:idx_review_comments_created,priority:2;index:idx_review_comments_review_created,priority:3;index:idx_review_comments_user_created,priority:3" json:"id"`
+	ReviewID uint           `gorm:"not null;index:idx_review_comments_review_created,priority:1" json:"review_id"`
+	Review   SideMenuReview `gorm:"foreignKey:ReviewID" json:"review"`
+	UserID   uint           `gorm:"not null;index:idx_review_comments_user_created,priority:1" json:"user_id"`
+	User     User           `gorm:"foreignKey:UserID" json:"user"`
+	// ParentID 返信先のコメント（トップレベルのコメントは nil）
+	ParentID *uint `gorm:"index" json:"parent_id"`
+	// RootID スレッドの起点となるトップレベルのコメント（トップレベルのコメントは nil）
+	RootID *uint `gorm:"index" json:"root_id"`
+	Depth  int   `gorm:"not null;default:0" json:"depth"`
+	// ReplyCount 直接の返信の件数（削除済みの返信は含まない）
+	ReplyCount int64  `gorm:"not null;default:0" json:"reply_count"`
+	Comment    string `gorm:"not null" json:"comment"`
+	// Mentions 本文中で @handle によりメンションしたユーザー
+	Mentions []CommentMention `gorm:"foreignKey:CommentID" json:"mentions"`
+	// IsDeleted 返信が残っているため本文だけを消したコメント
+	IsDeleted bool           `gorm:"not null;default:false" json:"is_deleted"`
+	CreatedAt time.Time      `gorm:"index:idx_review_comments_created,priority:1;index:idx_review_comments_review_created,priority:2;index:idx_review_comments_user_created,priority:2" json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	// Reactions リアクションの種類ごとの件数
+	Reactions ReactionCounts `gorm:"-" json:"reactions"`
+	// MyReactions 認証済みのリクエストで、そのユーザーが付けたリアクション
+	MyReactions []ReactionType `gorm:"-" json:"my_reactions,omitempty"`
+	// Replies ツリー形式で取得した場合の返信（古い順）
+	Replies []*ReviewComment `gorm:"-" json:"replies,omitempty"`
+}
+
+// AfterFind 本文を消したコメントは投稿者とメンションも伏せる
+// 返信のスレッドを保つために残しているだけのため、誰のコメントだったかも返さない。
+func (c *ReviewComment) AfterFind(tx *gorm.DB) error {
+	if c.IsDeleted {
+		c.UserID = 0
+		c.User = User{}
+		c.Mentions = nil
+	}
+	return nil
+}
+
+// AttachParent 返信先を検証し、スレッド内の位置（ParentID・RootID・Depth）を設定する
+func (c *ReviewComment) AttachParent(parent *ReviewComment) error {
+	if parent.ReviewID != c.ReviewID {
+		return fmt.Errorf("%w: 返信先のコメントは同じレビューのものを指定してください", ErrInvalidRequest)
+	}
+	if parent.IsDeleted {
+		return fmt.Errorf("%w: 削除されたコメントには返信できません", ErrInvalidRequest)
+	}
+	if parent.Depth >= MaxReviewCommentDepth {
+		return fmt.Errorf("%w: 返信は%d階層までです", ErrInvalidRequest, MaxReviewCommentDepth)
+	}
+
+	rootID := parent.ID
+	if parent.RootID != nil {
+		rootID = *parent.RootID
+	}
+	c.ParentID = &parent.ID
+	c.RootID = &rootID
+	c.Depth = parent.Depth + 1
+	return nil
+}
+
+// ReviewCommentMode レビュー別コメント一覧の出力形式
+type ReviewCommentMode string
+
+const (
+	// ReviewCommentModeFlat 返信を含む全コメントを新しい順に並べる
+	ReviewCommentModeFlat ReviewCommentMode = "flat"
+	// ReviewCommentModeTree トップレベルのコメントをページングし、返信を replies に入れ子にする
+	ReviewCommentModeTree ReviewCommentMode = "tree"
+)
+
+// ParseReviewCommentMode 出力形式を読み取る（空の場合は flat）
+func ParseReviewCommentMode(value string) (ReviewCommentMode, error) {
+	switch mode := ReviewCommentMode(value); mode {
+	case "":
+		return ReviewCommentModeFlat, nil
+	case ReviewCommentModeFlat, ReviewCommentModeTree:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: mode は flat または tree で指定してください", ErrInvalidCriteria)
+	}
+}
+
+// BuildReviewCommentTree トップレベルのコメントに、同じスレッドの返信を入れ子にして返す
+// replies は古い順に並んでいる前提で、各コメントの Replies もその順になる。
+func BuildReviewCommentTree(roots []*ReviewComment, replies []*ReviewComment) []*ReviewComment {
+	byID := make(map[uint]*ReviewComment, len(roots)+len(replies))
+	for _, root := range roots {
+		byID[root.ID] = root
+	}
+	for _, reply := range replies {
+		byID[reply.ID] = reply
+	}
+	for _, reply := range replies {
+		if reply.ParentID == nil {
+			continue
+		}
+		if parent, ok := byID[*reply.ParentID]; ok {
+			parent.Replies = append(parent.Replies, reply)
+		}
+	}
+	return roots
 }
 
 // CreateReviewCommentRequest レビューコメント作成リクエスト
 type CreateReviewCommentRequest struct {
 	ReviewID uint   `json:"review_id" binding:"required"`
 	Comment  string `json:"comment" binding:"required"`
+	// ParentID 返信先のコメント（省略時はトップレベルのコメント）
+	ParentID *uint `json:"parent_id"`
+}
+
+// UpdateReviewCommentRequest レビューコメント更新リクエスト
+type UpdateReviewCommentRequest struct {
+	Comment string `json:"comment" binding:"required"`
 }
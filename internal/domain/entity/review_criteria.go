@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReviewSort レビュー一覧の並び順
+type ReviewSort string
+
+const (
+	ReviewSortNewest        ReviewSort = "newest"
+	ReviewSortHighestRated  ReviewSort = "highest_rated"
+	ReviewSortMostLiked     ReviewSort = "most_liked"
+	ReviewSortMostCommented ReviewSort = "most_commented"
+	// ReviewSortTrending 定期ジョブが計算したトレンドスコアの高い順
+	ReviewSortTrending ReviewSort = "trending"
+)
+
+// IsValidReviewSort 定義済みの並び順かどうか
+func IsValidReviewSort(sort ReviewSort) bool {
+	switch sort {
+	case ReviewSortNewest, ReviewSortHighestRated, ReviewSortMostLiked, ReviewSortMostCommented, ReviewSortTrending:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReviewCriteria レビュー一覧の絞り込み条件と並び順
+// ゼロ値の項目は条件に含めない。SQLへの変換はリポジトリが行う。
+type ReviewCriteria struct {
+	StoreID      uint
+	StoreName    string
+	SideMenuID   uint
+	SideMenuName string
+	MinRating    int
+	MaxRating    int
+	UserID       uint
+	VerifiedOnly bool
+	HasImages    bool
+	// From 以降（含む）、To より前（含まない）に投稿されたレビューに絞り込む
+	From *time.Time
+	To   *time.Time
+	Sort ReviewSort
+}
+
+// Validate 条件の組み合わせが正しいか確認し、並び順の既定値を補う
+func (c *ReviewCriteria) Validate() error {
+	if c.Sort == "" {
+		c.Sort = ReviewSortNewest
+	}
+	if !IsValidReviewSort(c.Sort) {
+		return fmt.Errorf("%w: 並び順 %s はサポートされていません", ErrInvalidCriteria, c.Sort)
+	}
+	if c.MinRating < 0 || c.MinRating > 5 || c.MaxRating < 0 || c.MaxRating > 5 {
+		return fmt.Errorf("%w: 評価は1から5の範囲で指定してください", ErrInvalidCriteria)
+	}
+	if c.MinRating > 0 && c.MaxRating > 0 && c.MinRating > c.MaxRating {
+		return fmt.Errorf("%w: 評価の下限が上限を超えています", ErrInvalidCriteria)
+	}
+	if c.From != nil && c.To != nil && !c.From.Before(*c.To) {
+		return fmt.Errorf("%w: 期間の開始日は終了日より前にしてください", ErrInvalidCriteria)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReviewCriteriaValidate(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name     string
+		criteria ReviewCriteria
+		wantErr  bool
+		wantSort ReviewSort
+	}{
+		{name: "条件なしは新着順", criteria: ReviewCriteria{}, wantSort: ReviewSortNewest},
+		{name: "並び順の指定", criteria: ReviewCriteria{Sort: ReviewSortMostLiked}, wantSort: ReviewSortMostLiked},
+		{name: "未定義の並び順", criteria: ReviewCriteria{Sort: "oldest"}, wantErr: true},
+		{name: "評価の範囲", criteria: ReviewCriteria{MinRating: 3, MaxRating: 5}, wantSort: ReviewSortNewest},
+		{name: "評価の下限と上限が同じ", criteria: ReviewCriteria{MinRating: 4, MaxRating: 4}, wantSort: ReviewSortNewest},
+		{name: "評価の下限が上限を超える", criteria: ReviewCriteria{MinRating: 5, MaxRating: 2}, wantErr: true},
+		{name: "評価の下限が範囲外", criteria: ReviewCriteria{MinRating: 6}, wantErr: true},
+		{name: "評価の上限が負", criteria: ReviewCriteria{MaxRating: -1}, wantErr: true},
+		{name: "期間", criteria: ReviewCriteria{From: &from, To: &to}, wantSort: ReviewSortNewest},
+		{name: "開始日のみ", criteria: ReviewCriteria{From: &from}, wantSort: ReviewSortNewest},
+		{name: "開始日と終了日が同じ", criteria: ReviewCriteria{From: &from, To: &from}, wantErr: true},
+		{name: "開始日が終了日より後", criteria: ReviewCriteria{From: &to, To: &from}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := tt.criteria
+			err := criteria.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCriteria) {
+					t.Errorf("Validate() error = %v, want ErrInvalidCriteria", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if criteria.Sort != tt.wantSort {
+				t.Errorf("Sort = %s, want %s", criteria.Sort, tt.wantSort)
+			}
+		})
+	}
+}
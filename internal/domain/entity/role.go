@@ -0,0 +1,72 @@
+package entity
+
+// ユーザーのロール
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Permission ロールに付与される権限
+type Permission string
+
+const (
+	// PermissionModerateContent 他人のレビュー・コメント・画像を編集／削除できる
+	PermissionModerateContent Permission = "content:moderate"
+	// PermissionManageUsers ユーザーのロールを変更できる
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionManageStores 店舗を登録・編集・削除できる（店舗のサイドメニューを含む）
+	PermissionManageStores Permission = "stores:manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleUser:      {},
+	RoleModerator: {PermissionModerateContent, PermissionManageStores},
+	RoleAdmin:     {PermissionModerateContent, PermissionManageUsers, PermissionManageStores},
+}
+
+// IsValidRole 定義済みのロールかどうか
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission ロールが権限を持っているかどうか
+func RoleHasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Actor ユースケースを実行するユーザー
+type Actor struct {
+	UserID uint
+	Role   string
+}
+
+// Can 権限を持っているかどうか
+func (a *Actor) Can(permission Permission) bool {
+	return a != nil && RoleHasPermission(a.Role, permission)
+}
+
+// CanModify 所有者本人、またはコンテンツ管理権限を持つユーザーかどうか
+func (a *Actor) CanModify(ownerID uint) bool {
+	return a != nil && (a.UserID == ownerID || a.Can(PermissionModerateContent))
+}
+
+// リソースに対する操作（ForbiddenError のメッセージでリソース名に続けて表示する）
+const (
+	ActionEdit       = "を編集"
+	ActionDelete     = "を削除"
+	ActionAddImage   = "に画像を追加"
+	ActionChangeRole = "のロールを変更"
+	ActionCreate     = "を登録"
+)
+
+// UpdateUserRoleRequest ロール変更リクエスト
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user moderator admin"`
+}
@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// MaxSearchQueryLength 検索文字列の最大長（文字数）
+const MaxSearchQueryLength = 100
+
+// DefaultSearchVariantGroups 組み込みの表記ゆれ（同じ料理・食材を指す表記のグループ）
+// 読みの辞書を持たないため、かな・漢字の揺れは登録したものだけを同一視する。
+// 運用中に見つかった表記ゆれは SEARCH_VARIANTS_FILE で追加する。
+func DefaultSearchVariantGroups() [][]string {
+	return [][]string{
+		{"からあげ", "から揚げ", "唐揚げ", "唐揚", "空揚げ"},
+		{"えび", "海老"},
+		{"ぎょうざ", "餃子"},
+		{"しゅうまい", "しゅーまい", "焼売"},
+		{"はるまき", "春巻き", "春巻"},
+		{"てんぷら", "天ぷら", "天麩羅"},
+		{"ちゃーはん", "炒飯"},
+		{"たまご", "玉子", "卵"},
+		{"ごま", "胡麻"},
+		{"しょうが", "生姜"},
+		{"ねぎ", "葱"},
+		{"にんにく", "大蒜"},
+		{"かぼちゃ", "南瓜"},
+		{"ごぼう", "牛蒡"},
+		{"れんこん", "蓮根"},
+		{"にんじん", "人参"},
+		{"なす", "茄子"},
+		{"みそ", "味噌"},
+		{"しょうゆ", "醤油"},
+		{"うどん", "饂飩"},
+		{"そば", "蕎麦"},
+		{"あんにん", "杏仁"},
+		{"こーひー", "珈琲"},
+	}
+}
+
+// SearchVariants 表記ゆれの辞書
+type SearchVariants struct {
+	// groups 正規化済みの表記のグループ（グループ内は長い表記から並べる）
+	groups [][]string
+}
+
+// NewSearchVariants 表記ゆれのグループから辞書を作る
+// 各表記は検索文字列と同じ規則で正規化し、重複を除いて2つ未満になったグループは無視する。
+func NewSearchVariants(groups [][]string) SearchVariants {
+	var variants SearchVariants
+	for _, group := range groups {
+		seen := make(map[string]bool)
+		var members []string
+		for _, member := range group {
+			normalized := NormalizeSearchText(member)
+			if normalized == "" || seen[normalized] {
+				continue
+			}
+			seen[normalized] = true
+			members = append(members, normalized)
+		}
+		if len(members) < 2 {
+			continue
+		}
+		// 長い表記を優先して一致させる（「唐揚げ」を「唐揚」より先に見る）
+		sort.SliceStable(members, func(i, j int) bool {
+			return len(members[i]) > len(members[j])
+		})
+		variants.groups = append(variants.groups, members)
+	}
+	return variants
+}
+
+// DefaultSearchVariants 組み込みの表記ゆれの辞書
+func DefaultSearchVariants() SearchVariants {
+	return NewSearchVariants(DefaultSearchVariantGroups())
+}
+
+// expand 語に含まれる表記ゆれを、同じグループの別表記に置き換えた候補を返す
+func (v SearchVariants) expand(word string) []string {
+	candidates := []string{word}
+	for _, group := range v.groups {
+		for _, member := range group {
+			if !strings.Contains(word, member) {
+				continue
+			}
+			for _, other := range group {
+				if other != member {
+					candidates = append(candidates, strings.ReplaceAll(word, member, other))
+				}
+			}
+			break
+		}
+	}
+	return candidates
+}
+
+// FoldSearchText 検索用に文字種の違いを吸収する
+// 全角英数・半角カナを NFKC で統一し、英字を小文字に、カタカナをひらがなに変換する。
+// 空白の扱いは変えないため、元の文字列との位置の対応付けにも使える。
+func FoldSearchText(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+	return strings.Map(func(r rune) rune {
+		// ァ(U+30A1)〜ヶ(U+30F6) を ぁ(U+3041)〜ゖ(U+3096) に寄せる
+		if r >= 0x30A1 && r <= 0x30F6 {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// NormalizeSearchText FoldSearchText に加えて連続する空白を1つにまとめる
+func NormalizeSearchText(s string) string {
+	return strings.Join(strings.FieldsFunc(FoldSearchText(s), unicode.IsSpace), " ")
+}
+
+// SearchQuery 正規化済みの検索条件
+type SearchQuery struct {
+	// Text 正規化した検索文字列全体（関連度の計算に使う）
+	Text string
+	// Terms 空白で区切った語ごとの候補（表記ゆれを含む）
+	// すべての語について、いずれかの候補を含むレビューが検索対象になる。
+	Terms [][]string
+}
+
+// NewSearchQuery 入力された検索文字列を正規化し、語ごとの表記ゆれ候補を辞書から展開する
+func NewSearchQuery(raw string, variants SearchVariants) (SearchQuery, error) {
+	text := NormalizeSearchText(raw)
+	if text == "" {
+		return SearchQuery{}, fmt.Errorf("%w: 検索キーワードを入力してください", ErrInvalidCriteria)
+	}
+	if len([]rune(text)) > MaxSearchQueryLength {
+		return SearchQuery{}, fmt.Errorf("%w: 検索キーワードは%d文字以内で入力してください", ErrInvalidCriteria, MaxSearchQueryLength)
+	}
+
+	query := SearchQuery{Text: text}
+	for _, word := range strings.Fields(text) {
+		query.Terms = append(query.Terms, variants.expand(word))
+	}
+	return query, nil
+}
+
+// Candidates すべての語の候補を重複なく返す（ハイライトに使う）
+func (q SearchQuery) Candidates() []string {
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, term := range q.Terms {
+		for _, candidate := range term {
+			if !seen[candidate] {
+				seen[candidate] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	// 長い候補を優先して一致させる
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i]) > len(candidates[j])
+	})
+	return candidates
+}
+
+// ReviewSearchHit 全文検索の結果
+type ReviewSearchHit struct {
+	Review *SideMenuReview `json:"review"`
+	// Score 関連度（大きいほど一致度が高い）
+	Score float64 `json:"score"`
+	// Highlights 一致した箇所を <mark> で囲んだフィールドごとの抜粋（HTMLエスケープ済み）
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// BeforeSave 検索用の正規化テキストを更新する
+func (r *SideMenuReview) BeforeSave(tx *gorm.DB) error {
+	r.SearchKey, r.SearchText = r.BuildSearchText()
+	return nil
+}
+
+// BuildSearchText 検索用の正規化テキストを組み立てる
+// key は店舗名・サイドメニュー名・タイトル、text はそれにコメントを加えたもの。
+func (r *SideMenuReview) BuildSearchText() (key string, text string) {
+	key = NormalizeSearchText(strings.Join([]string{r.StoreName, r.SideMenuName, r.Title}, " "))
+	text = NormalizeSearchText(key + " " + r.Comment)
+	return key, text
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewSearchQuery(t *testing.T) {
+	variants := NewSearchVariants([][]string{
+		{"からあげ", "唐揚げ", "唐揚"},
+		{"ポテト", "ぽてと"}, // 正規化すると同じ表記になるため無視する
+		{"えび"},         // 表記が1つだけのグループは無視する
+	})
+
+	tests := []struct {
+		name  string
+		raw   string
+		text  string
+		terms [][]string
+	}{
+		{
+			name:  "文字種と空白を正規化する",
+			raw:   "  ﾎﾟﾃﾄ　ＬＬ ",
+			text:  "ぽてと ll",
+			terms: [][]string{{"ぽてと"}, {"ll"}},
+		},
+		{
+			name:  "長い表記を優先して別表記に展開する",
+			raw:   "唐揚げ弁当",
+			text:  "唐揚げ弁当",
+			terms: [][]string{{"唐揚げ弁当", "からあげ弁当", "唐揚弁当"}},
+		},
+		{
+			name:  "カタカナの表記もひらがなとして展開する",
+			raw:   "カラアゲ",
+			text:  "からあげ",
+			terms: [][]string{{"からあげ", "唐揚げ", "唐揚"}},
+		},
+		{
+			name:  "辞書にない語はそのまま",
+			raw:   "えび",
+			text:  "えび",
+			terms: [][]string{{"えび"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := NewSearchQuery(tt.raw, variants)
+			if err != nil {
+				t.Fatalf("NewSearchQuery(%q) error = %v", tt.raw, err)
+			}
+			if query.Text != tt.text {
+				t.Errorf("Text = %q, want %q", query.Text, tt.text)
+			}
+			if !reflect.DeepEqual(query.Terms, tt.terms) {
+				t.Errorf("Terms = %q, want %q", query.Terms, tt.terms)
+			}
+		})
+	}
+}
+
+func TestNewSearchQueryInvalid(t *testing.T) {
+	for _, raw := range []string{"", "　 ", strings.Repeat("あ", MaxSearchQueryLength+1)} {
+		if _, err := NewSearchQuery(raw, DefaultSearchVariants()); !errors.Is(err, ErrInvalidCriteria) {
+			t.Errorf("NewSearchQuery(%q) error = %v, want ErrInvalidCriteria", raw, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// サイドメニューのカテゴリ
+const (
+	SideMenuCategoryFries        = "fries"
+	SideMenuCategoryFriedChicken = "fried_chicken"
+	SideMenuCategorySalad        = "salad"
+	SideMenuCategorySoup         = "soup"
+	SideMenuCategoryDessert      = "dessert"
+	SideMenuCategoryDrink        = "drink"
+	SideMenuCategoryOther        = "other"
+)
+
+// IsValidSideMenuCategory 定義済みのカテゴリかどうか
+func IsValidSideMenuCategory(category string) bool {
+	switch category {
+	case SideMenuCategoryFries, SideMenuCategoryFriedChicken, SideMenuCategorySalad, SideMenuCategorySoup,
+		SideMenuCategoryDessert, SideMenuCategoryDrink, SideMenuCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// SideMenu 店舗のサイドメニュー（レビューの対象となる商品）
+// 同じ店舗内では正規化したメニュー名で名寄せする。
+type SideMenu struct {
+	ID             uint   `gorm:"primaryKey" json:"id"`
+	StoreID        uint   `gorm:"not null;uniqueIndex:idx_side_menus_store_name" json:"store_id"`
+	Store          *Store `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE" json:"store,omitempty"`
+	Name           string `gorm:"not null" json:"name"`
+	NormalizedName string `gorm:"not null;uniqueIndex:idx_side_menus_store_name" json:"-"`
+	Description    string `json:"description"`
+	Category       string `gorm:"not null;default:other;index" json:"category"`
+	// Price 税込価格（円）
+	Price    *int `json:"price"`
+	Calories *int `json:"calories"`
+	// IsAvailable 販売中かどうか（販売終了後もレビューは残す）
+	IsAvailable bool      `gorm:"not null" json:"is_available"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BeforeSave 名寄せ用の正規化したメニュー名を更新する
+func (m *SideMenu) BeforeSave(tx *gorm.DB) error {
+	m.NormalizedName = NormalizeStoreName(m.Name)
+	if m.Category == "" {
+		m.Category = SideMenuCategoryOther
+	}
+	return nil
+}
+
+// SideMenuDetail サイドメニューと評価の集計値
+type SideMenuDetail struct {
+	*SideMenu
+	Stats RatingSummary `json:"stats"`
+}
+
+// SideMenuRequest サイドメニューの作成・更新リクエスト
+type SideMenuRequest struct {
+	StoreID     uint   `json:"store_id" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Category    string `json:"category" binding:"omitempty,oneof=fries fried_chicken salad soup dessert drink other"`
+	Price       *int   `json:"price" binding:"omitempty,min=0"`
+	Calories    *int   `json:"calories" binding:"omitempty,min=0"`
+	// IsAvailable 省略時は販売中
+	IsAvailable *bool `json:"is_available"`
+}
+
+// SideMenuFilter サイドメニュー一覧の絞り込み条件
+type SideMenuFilter struct {
+	StoreID uint
+	// Category 空の場合はすべてのカテゴリ
+	Category string
+	// Keyword メニュー名の部分一致
+	Keyword string
+	// AvailableOnly 販売中のメニューのみ
+	AvailableOnly bool
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Store 店舗エンティティ
+// 表記ゆれ（略称・英語表記など）は StoreAlias として登録し、同じ店舗に名寄せする。
+type Store struct {
+	ID             uint         `gorm:"primaryKey" json:"id"`
+	Name           string       `gorm:"not null" json:"name"`
+	NormalizedName string       `gorm:"not null;uniqueIndex" json:"-"`
+	Chain          string       `json:"chain"`
+	Address        string       `json:"address"`
+	Phone          string       `json:"phone"`
+	Latitude       *float64     `json:"latitude"`
+	Longitude      *float64     `json:"longitude"`
+	Aliases        []StoreAlias `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE" json:"aliases"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// StoreAlias 店舗の別名
+type StoreAlias struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	StoreID        uint      `gorm:"not null;index" json:"store_id"`
+	Name           string    `gorm:"not null" json:"name"`
+	NormalizedName string    `gorm:"not null;uniqueIndex" json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// BeforeSave 名寄せ用の正規化した店舗名を更新する
+func (s *Store) BeforeSave(tx *gorm.DB) error {
+	s.NormalizedName = NormalizeStoreName(s.Name)
+	return nil
+}
+
+// BeforeSave 名寄せ用の正規化した別名を更新する
+func (a *StoreAlias) BeforeSave(tx *gorm.DB) error {
+	a.NormalizedName = NormalizeStoreName(a.Name)
+	return nil
+}
+
+// NormalizeStoreName 店舗名を名寄せ用に正規化する
+// 検索と同じ文字種の統一に加えて、空白と記号（・ ' - など）を取り除く。
+func NormalizeStoreName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '・', '\'', '’', '-', '.', '&':
+			return -1
+		}
+		return r
+	}, NormalizeSearchText(name))
+}
+
+// Names 店舗名と別名をまとめて返す
+func (s *Store) Names() []string {
+	names := []string{s.Name}
+	for _, alias := range s.Aliases {
+		names = append(names, alias.Name)
+	}
+	return names
+}
+
+// StoreDetail 店舗と評価の集計値
+type StoreDetail struct {
+	*Store
+	Stats RatingSummary `json:"stats"`
+}
+
+// StoreRequest 店舗の作成・更新リクエスト
+// 更新時は別名を含めてリクエストの内容で置き換える。
+type StoreRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Chain     string   `json:"chain"`
+	Address   string   `json:"address"`
+	Phone     string   `json:"phone"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Aliases   []string `json:"aliases" binding:"omitempty,dive,required"`
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// TrendingScoreScale トレンドスコアを整数で保持するための倍率
+const TrendingScoreScale = 1000000
+
+// TrendingSnapshotRetention 更新前のトレンドスコアを残す期間
+// トレンド順のカーソルは発行時の世代のスコアで並べ直すため、この期間を過ぎた世代のカーソルは無効になる。
+const TrendingSnapshotRetention = time.Hour
+
+// TrendingPolicy トレンドスコアの計算方法
+// スコアは (1 + いいね数×LikeWeight + コメント数×CommentWeight) × 0.5^(経過時間 / HalfLife) で、
+// 反応が多く新しいレビューほど高くなる。
+type TrendingPolicy struct {
+	LikeWeight    float64
+	CommentWeight float64
+	// HalfLife スコアが半分になるまでの時間
+	HalfLife time.Duration
+}
+
+// DefaultTrendingPolicy 既定の計算方法（コメントはいいねの2倍の重み、半減期24時間）
+func DefaultTrendingPolicy() TrendingPolicy {
+	return TrendingPolicy{
+		LikeWeight:    1,
+		CommentWeight: 2,
+		HalfLife:      24 * time.Hour,
+	}
+}
+
+// Window スコアを計算し直す対象期間（これより古いレビューのスコアは 0 とする）
+// 半減期の10倍が経過するとスコアは元の約0.1%になるため、並び順への影響は無視できる。
+func (p TrendingPolicy) Window() time.Duration {
+	return 10 * p.HalfLife
+}
+
+// Score 保存用のトレンドスコア（×TrendingScoreScale の整数）
+// 0.5^(経過時間 / HalfLife) はすべてのレビューに同じ係数 0.5^(現在時刻 / HalfLife) を掛けた形に分けられるため、
+// 並び順を変えずに係数を除いた log2(1 + いいね数×LikeWeight + コメント数×CommentWeight) + 投稿時刻 / HalfLife を保存する。
+// 時間の経過では値が変わらず、いいね・コメントが増減したときだけ更新すればよい。
+func (p TrendingPolicy) Score(likes, comments int64, createdAt time.Time) int64 {
+	engagement := 1 + float64(likes)*p.LikeWeight + float64(comments)*p.CommentWeight
+	halfLives := float64(createdAt.UnixMicro()) / float64(p.HalfLife.Microseconds())
+	return int64(math.Round((math.Log2(engagement) + halfLives) * TrendingScoreScale))
+}
+
+// TrendingGeneration トレンドスコアを更新した世代
+// トレンド順のカーソルは世代を記録し、次のページも同じ世代のスコアで並べる。
+type TrendingGeneration struct {
+	ID        uint64    `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"not null;index"`
+}
+
+// TrendingScoreChange 世代の更新で書き換えられる前のトレンドスコア
+// インデックス idx_trending_score_changes_review_generation はレビューごとに後の世代の変更を探す用
+// ある世代のスコアは、それより後の世代で最初に書き換えられる前の値（書き換えられていなければ現在の値）になる。
+type TrendingScoreChange struct {
+	Generation    uint64 `gorm:"primaryKey;autoIncrement:false;index:idx_trending_score_changes_review_generation,priority:2"`
+	ReviewID      uint   `gorm:"primaryKey;autoIncrement:false;index:idx_trending_score_changes_review_generation,priority:1"`
+	PreviousScore int64  `gorm:"not null"`
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTrendingPolicyScore(t *testing.T) {
+	policy := DefaultTrendingPolicy()
+	now := time.Date(2025, 10, 22, 12, 0, 0, 0, time.UTC)
+
+	t.Run("半減期1つ分古いレビューは反応が2倍で同じスコアになる", func(t *testing.T) {
+		// (1 + 1×1 + 0×2) = 2 と (1 + 3×1 + 0×2) = 4
+		newer := policy.Score(1, 0, now)
+		older := policy.Score(3, 0, now.Add(-policy.HalfLife))
+		if newer != older {
+			t.Errorf("Score = %d, %d, want equal", newer, older)
+		}
+	})
+
+	t.Run("反応が同じなら新しいレビューほど高い", func(t *testing.T) {
+		if policy.Score(5, 1, now) <= policy.Score(5, 1, now.Add(-time.Minute)) {
+			t.Error("新しいレビューのスコアが高くなっていません")
+		}
+	})
+
+	t.Run("コメントはいいねの重みで数える", func(t *testing.T) {
+		if got, want := policy.Score(0, 1, now), policy.Score(2, 0, now); got != want {
+			t.Errorf("Score(0, 1) = %d, want Score(2, 0) = %d", got, want)
+		}
+	})
+
+	t.Run("減衰させたスコアと同じ並び順になる", func(t *testing.T) {
+		reviews := []struct {
+			likes, comments int64
+			age             time.Duration
+		}{
+			{0, 0, 0},
+			{10, 0, 30 * time.Hour},
+			{3, 2, 6 * time.Hour},
+			{50, 10, 5 * 24 * time.Hour},
+			{1, 0, 2 * time.Hour},
+		}
+		decayed := func(likes, comments int64, age time.Duration) float64 {
+			engagement := 1 + float64(likes)*policy.LikeWeight + float64(comments)*policy.CommentWeight
+			return engagement * math.Pow(0.5, age.Hours()/policy.HalfLife.Hours())
+		}
+
+		for i, a := range reviews {
+			for j, b := range reviews {
+				if i == j {
+					continue
+				}
+				want := decayed(a.likes, a.comments, a.age) > decayed(b.likes, b.comments, b.age)
+				got := policy.Score(a.likes, a.comments, now.Add(-a.age)) > policy.Score(b.likes, b.comments, now.Add(-b.age))
+				if got != want {
+					t.Errorf("レビュー%d と レビュー%d の並び順が一致しません", i, j)
+				}
+			}
+		}
+	})
+}
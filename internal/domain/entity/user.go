@@ -7,12 +7,33 @@ import (
 )
 
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Email     string    `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	Name      string    `json:"name" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Email           string     `json:"email" gorm:"uniqueIndex;not null"`
+	Password        string     `json:"-" gorm:"not null"`
+	Handle          string     `json:"handle" gorm:"size:30;uniqueIndex;not null"`
+	Name            string     `json:"name" gorm:"not null"`
+	Role            string     `json:"role" gorm:"not null;default:user"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// TokenGeneration 一括失効の世代。これより小さい世代で発行されたアクセストークンは無効
+	// 一括失効でのみ更新し、ユーザーの保存（Save）で古い値に戻らないよう書き込みを禁止する。
+	TokenGeneration uint64 `json:"-" gorm:"<-:false;not null;default:0"`
+}
+
+// Profile 他のユーザーに公開するプロフィール
+func (u *User) Profile() *UserProfile {
+	return &UserProfile{ID: u.ID, Handle: u.Handle, Name: u.Name}
+}
+
+// IsEmailVerified メールアドレスが確認済みかどうか
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// HasPermission ユーザーのロールが権限を持っているかどうか
+func (u *User) HasPermission(permission Permission) bool {
+	return RoleHasPermission(u.Role, permission)
 }
 
 // HashPassword パスワードをハッシュ化
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// EmailVerificationTokenRepository メールアドレス確認トークンリポジトリインターフェース
+type EmailVerificationTokenRepository interface {
+	Create(token *entity.EmailVerificationToken) error
+	GetByTokenHash(tokenHash string) (*entity.EmailVerificationToken, error)
+	// GetLatestByUserID 直近に発行したトークンを返す。発行履歴がない場合は nil
+	GetLatestByUserID(userID uint) (*entity.EmailVerificationToken, error)
+	CountCreatedSince(userID uint, since time.Time) (int64, error)
+	// MarkUsed 未使用のトークンを使用済みにする。更新できた場合のみ true を返す
+	MarkUsed(id uint, usedAt time.Time) (bool, error)
+	InvalidateByUserID(userID uint, usedAt time.Time) error
+}
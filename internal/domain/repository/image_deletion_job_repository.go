@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// ImageDeletionJobRepository 画像削除ジョブリポジトリインターフェース
+// ジョブの登録は画像の削除と同じトランザクションで ReviewRepository が行う。
+type ImageDeletionJobRepository interface {
+	// GetDueImageDeletionJobs 保存先 provider のジョブのうち、再試行の上限に達しておらず now までに実行すべきものを古い順に返す
+	GetDueImageDeletionJobs(provider string, now time.Time, limit int) ([]*entity.ImageDeletionJob, error)
+	// GetQueuedImageKeys keys のうち、保存先 provider の削除ジョブに登録されているキーを返す（再試行の上限に達したものを含む）
+	GetQueuedImageKeys(provider string, keys []string) (map[string]bool, error)
+	UpdateImageDeletionJob(job *entity.ImageDeletionJob) error
+	DeleteImageDeletionJob(id uint) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// NotificationRepository 通知リポジトリインターフェース
+type NotificationRepository interface {
+	CreateNotification(notification *entity.Notification) error
+	// ExistsSince 宛先・種類・操作したユーザー・対象のレビューとコメントが同じ通知が since 以降に作成されているか
+	ExistsSince(notification *entity.Notification, since time.Time) (bool, error)
+	GetNotificationsByUserID(userID uint, unreadOnly bool, page entity.PageRequest) ([]*entity.Notification, *entity.PageInfo, error)
+	CountUnread(userID uint) (int64, error)
+	// MarkRead ユーザー宛ての通知を既読にする（該当する通知がない場合は false を返す）
+	MarkRead(userID uint, id uint) (bool, error)
+	// MarkAllRead ユーザー宛ての未読の通知をすべて既読にし、更新した件数を返す
+	MarkAllRead(userID uint) (int64, error)
+	// GetPreferences 保存されている受信設定のみを返す
+	GetPreferences(userID uint) (entity.NotificationPreferences, error)
+	SavePreferences(userID uint, prefs entity.NotificationPreferences) error
+}
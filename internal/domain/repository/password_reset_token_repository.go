@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// PasswordResetTokenRepository パスワードリセットトークンリポジトリインターフェース
+type PasswordResetTokenRepository interface {
+	Create(token *entity.PasswordResetToken) error
+	GetByTokenHash(tokenHash string) (*entity.PasswordResetToken, error)
+	// GetLatestByUserID 直近に発行したトークンを返す。発行履歴がない場合は nil
+	GetLatestByUserID(userID uint) (*entity.PasswordResetToken, error)
+	CountCreatedSince(userID uint, since time.Time) (int64, error)
+	// MarkUsed 未使用のトークンを使用済みにする。更新できた場合のみ true を返す
+	MarkUsed(id uint, usedAt time.Time) (bool, error)
+	// InvalidateByUserID ユーザーの未使用トークンをすべて使用済みにする
+	InvalidateByUserID(userID uint, usedAt time.Time) error
+}
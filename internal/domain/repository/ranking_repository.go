@@ -0,0 +1,11 @@
+package repository
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// RankingRepository ランキング集計リポジトリインターフェース
+type RankingRepository interface {
+	// RankSideMenus ベイズ平均の高い順にサイドメニューを返す
+	RankSideMenus(params entity.RankingParams) ([]*entity.SideMenuRanking, error)
+	// RankStores ベイズ平均の高い順に店舗を返す（カテゴリ指定時はそのカテゴリのサイドメニューのレビューで集計する）
+	RankStores(params entity.RankingParams) ([]*entity.StoreRanking, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// RatingStatsRepository 評価集計リポジトリインターフェース
+// 集計の更新はレビューの書き込みと同じトランザクションで ReviewRepository が行う。
+type RatingStatsRepository interface {
+	// Get 対象の集計を返す（レビューがまだない場合はゼロ値の集計）
+	Get(subjectType string, subjectID uint) (*entity.RatingStats, error)
+	// Rebuild レビューから集計を作り直す
+	Rebuild() error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// ReactionRepository リアクションリポジトリインターフェース
+type ReactionRepository interface {
+	// AddReaction リアクションを登録する（既に同じリアクションがある場合は false を返す）
+	AddReaction(reaction *entity.Reaction) (bool, error)
+	// RemoveReaction リアクションを取り消す（リアクションがなかった場合は false を返す）
+	RemoveReaction(targetType entity.ReactionTargetType, targetID uint, userID uint, reactionType entity.ReactionType) (bool, error)
+	// CountReactions 対象ごとにリアクションの種類別の件数を返す
+	CountReactions(targetType entity.ReactionTargetType, targetIDs []uint) (map[uint]entity.ReactionCounts, error)
+	// GetUserReactions 対象ごとにユーザーが付けたリアクションを返す
+	GetUserReactions(targetType entity.ReactionTargetType, targetIDs []uint, userID uint) (map[uint][]entity.ReactionType, error)
+}
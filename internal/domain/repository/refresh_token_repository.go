@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// RefreshTokenRepository リフレッシュトークンリポジトリインターフェース
+type RefreshTokenRepository interface {
+	Create(token *entity.RefreshToken) error
+	GetByTokenHash(tokenHash string) (*entity.RefreshToken, error)
+	// MarkRotated 未使用かつ未失効のトークンを使用済みにする。更新できた場合のみ true を返す
+	MarkRotated(id uint, rotatedAt time.Time) (bool, error)
+	RevokeFamily(familyID string, revokedAt time.Time) error
+	RevokeAllByUserID(userID uint, revokedAt time.Time) error
+	// DeleteExpired 有効期限が before より前のトークンを削除し、削除した件数を返す
+	DeleteExpired(before time.Time) (int64, error)
+}
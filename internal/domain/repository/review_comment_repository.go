@@ -4,11 +4,21 @@ import "sidemenulab-backend/internal/domain/entity"
 
 // ReviewCommentRepository レビューコメントリポジトリインターフェース
 type ReviewCommentRepository interface {
+	// CreateReviewComment comment.Mentions も一緒に登録し、返信の場合は返信先をロックして検証し直したうえで返信数も更新する
 	CreateReviewComment(comment *entity.ReviewComment) error
 	GetReviewCommentByID(id uint) (*entity.ReviewComment, error)
-	GetReviewCommentsByReviewID(reviewID uint) ([]*entity.ReviewComment, error)
-	GetReviewCommentsByUserID(userID uint) ([]*entity.ReviewComment, error)
-	GetAllReviewComments() ([]*entity.ReviewComment, error)
+	GetReviewCommentsByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	// GetTopLevelReviewCommentsByReviewID 返信ではないコメントのみをページングして取得する
+	GetTopLevelReviewCommentsByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	// GetRepliesByRootIDs 指定したスレッドに属する全ての返信を古い順に取得する
+	GetRepliesByRootIDs(rootIDs []uint) ([]*entity.ReviewComment, error)
+	GetRepliesByParentID(parentID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	GetReviewCommentsByUserID(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	// GetReviewCommentsMentioningUser ユーザーをメンションしているコメントを新しい順に取得する
+	GetReviewCommentsMentioningUser(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	GetAllReviewComments(page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	// UpdateReviewComment 本文を更新し、メンションを comment.Mentions で置き換える
 	UpdateReviewComment(comment *entity.ReviewComment) error
+	// DeleteReviewComment 返信が残っている場合は本文を消したプレースホルダーとして残す
 	DeleteReviewComment(id uint) error
 }
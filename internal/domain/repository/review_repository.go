@@ -5,16 +5,31 @@ import "sidemenulab-backend/internal/domain/entity"
 type ReviewRepository interface {
 	CreateReview(review *entity.SideMenuReview) error
 	GetReviewByID(id uint) (*entity.SideMenuReview, error)
-	GetReviewsByStoreName(storeName string) ([]*entity.SideMenuReview, error)
-	GetReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error)
-	GetAllReviews() ([]*entity.SideMenuReview, error)
-	GetLikedReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error)
+	GetReviewsByStoreName(storeName string, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	GetReviewsByStoreID(storeID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	GetReviewsBySideMenuID(sideMenuID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	GetReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	SearchReviews(criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	// GetLikedReviewsByUserID いいねした日時の新しい順。カーソルはいいねの (created_at, id) を指す
+	GetLikedReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	// RefreshTrendingScores 対象期間内のレビューのトレンドスコアを計算し直し、値が変わった件数を返す
+	// 変わったスコアは新しい世代として記録し、更新前の値を保持期間（entity.TrendingSnapshotRetention）の間残す。
+	RefreshTrendingScores(policy entity.TrendingPolicy) (int64, error)
 	UpdateReview(review *entity.SideMenuReview) error
 	DeleteReview(id uint) error
 	CreateReviewImage(image *entity.SideMenuReviewImage) error
+	GetReviewImageByID(imageID uint) (*entity.SideMenuReviewImage, error)
 	GetReviewImagesByReviewID(reviewID uint) ([]*entity.SideMenuReviewImage, error)
 	DeleteReviewImage(imageID uint) error
-	CreateReviewLike(like *entity.SideMenuReviewLike) error
-	DeleteReviewLike(reviewID uint, userID uint) error
-	GetReviewLikesByReviewID(reviewID uint) ([]*entity.SideMenuReviewLike, error)
+	// GetReferencedImageKeys keys のうち、保存先 provider の画像の行から参照されているキーを返す
+	GetReferencedImageKeys(provider string, keys []string) (map[string]bool, error)
+	// GetUnkeyedReviewImageURLs 保存先のキーを記録していない画像（外部URLやキーの記録前の画像）のURLを返す
+	GetUnkeyedReviewImageURLs() ([]string, error)
+	// CreateReviewLike いいねを登録する（既にいいねしている場合は既存のいいねを like に読み込んで false を返す）
+	CreateReviewLike(like *entity.SideMenuReviewLike) (bool, error)
+	// DeleteReviewLike いいねを取り消す（いいねしていない場合も成功とし、false を返す）
+	DeleteReviewLike(reviewID uint, userID uint) (bool, error)
+	// GetLikedReviewIDs reviewIDs のうち、ユーザーがいいねしているレビューのIDを返す
+	GetLikedReviewIDs(userID uint, reviewIDs []uint) (map[uint]bool, error)
+	GetReviewLikesByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.SideMenuReviewLike, *entity.PageInfo, error)
 }
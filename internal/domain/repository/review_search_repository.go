@@ -0,0 +1,9 @@
+package repository
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// ReviewSearchRepository レビューの全文検索リポジトリインターフェース
+type ReviewSearchRepository interface {
+	// SearchReviews 検索語に一致するレビューを関連度の高い順に返す（Highlights は設定しない）
+	SearchReviews(query entity.SearchQuery, criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.ReviewSearchHit, *entity.PageInfo, error)
+}
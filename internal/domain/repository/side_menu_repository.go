@@ -0,0 +1,19 @@
+package repository
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// SideMenuRepository サイドメニューリポジトリインターフェース
+type SideMenuRepository interface {
+	Create(menu *entity.SideMenu) error
+	GetByID(id uint) (*entity.SideMenu, error)
+	// FindByName 店舗内でメニュー名が正規化して一致するサイドメニューを返す（見つからない場合は nil, nil）
+	FindByName(storeID uint, name string) (*entity.SideMenu, error)
+	// FindOrCreateByName 一致するサイドメニューがなければ、その名前で作成して返す
+	FindOrCreateByName(storeID uint, name string) (*entity.SideMenu, error)
+	List(filter entity.SideMenuFilter, page entity.PageRequest) ([]*entity.SideMenu, *entity.PageInfo, error)
+	// Update サイドメニューを更新する
+	// メニュー名が変わった場合は、紐付いたレビューのメニュー名と検索用テキストも更新する。
+	Update(menu *entity.SideMenu) error
+	Delete(id uint) error
+	CountReviews(menuID uint) (int64, error)
+}
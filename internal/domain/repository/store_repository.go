@@ -0,0 +1,21 @@
+package repository
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// StoreRepository 店舗リポジトリインターフェース
+type StoreRepository interface {
+	// Create 店舗を別名と一緒に作成する
+	Create(store *entity.Store) error
+	GetByID(id uint) (*entity.Store, error)
+	// FindByName 店舗名または別名が正規化して一致する店舗を返す（見つからない場合は nil, nil）
+	FindByName(name string) (*entity.Store, error)
+	// FindOrCreateByName 一致する店舗がなければ、その名前で店舗を作成して返す
+	FindOrCreateByName(name string) (*entity.Store, error)
+	// List 店舗名・別名に keyword を含む店舗の一覧（keyword が空の場合はすべて）
+	List(keyword string, page entity.PageRequest) ([]*entity.Store, *entity.PageInfo, error)
+	// Update 店舗情報を更新し、別名を store.Aliases で置き換える
+	// 店舗名が変わった場合は、紐付いたレビューの店舗名と検索用テキストも更新する。
+	Update(store *entity.Store) error
+	Delete(id uint) error
+	CountReviews(storeID uint) (int64, error)
+}
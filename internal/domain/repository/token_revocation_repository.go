@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// TokenRevocationRepository アクセストークン失効リストのリポジトリインターフェース
+type TokenRevocationRepository interface {
+	RevokeToken(token *entity.RevokedToken) error
+	IsTokenRevoked(jti string) (bool, error)
+	// RevokeAllForUser ユーザーの一括失効の世代を1つ進め、新しい世代を返す
+	RevokeAllForUser(userID uint) (uint64, error)
+	// GetUserTokenGeneration ユーザーの一括失効の世代を返す（ユーザーが存在しない場合は 0）
+	GetUserTokenGeneration(userID uint) (uint64, error)
+	// DeleteExpired 有効期限が before より前の失効リストの行を削除し、削除した件数を返す
+	DeleteExpired(before time.Time) (int64, error)
+}
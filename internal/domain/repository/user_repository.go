@@ -6,6 +6,11 @@ type UserRepository interface {
 	Create(user *entity.User) error
 	GetByEmail(email string) (*entity.User, error)
 	GetByID(id uint) (*entity.User, error)
+	GetByHandle(handle string) (*entity.User, error)
+	// GetByHandles 存在するハンドルのユーザーのみを返す
+	GetByHandles(handles []string) ([]*entity.User, error)
+	// SearchByHandlePrefix ハンドルが prefix で始まるユーザーをハンドル順に返す
+	SearchByHandlePrefix(prefix string, limit int) ([]*entity.User, error)
 	Update(user *entity.User) error
 	Delete(id uint) error
 }
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+)
+
+// maxEntries キャッシュの件数の上限
+// 超えた場合は期限切れのエントリを掃除し、それでも超えている場合はすべて破棄する（正はリポジトリ側にある）。
+const maxEntries = 10000
+
+type tokenEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+type userEntry struct {
+	generation uint64
+	expiresAt  time.Time
+}
+
+// TokenRevocationCache 失効リストのリポジトリの前段に置くインメモリキャッシュ
+// 認証のたびにデータベースへ問い合わせないよう、照会結果を ttl の間保持する。
+// このプロセスでの失効は即座に反映され、他のインスタンスでの失効は最大 ttl 遅れて反映される。
+type TokenRevocationCache struct {
+	repo   repository.TokenRevocationRepository
+	ttl    time.Duration
+	mu     sync.RWMutex
+	tokens map[string]tokenEntry
+	users  map[uint]userEntry
+}
+
+func NewTokenRevocationCache(repo repository.TokenRevocationRepository, ttl time.Duration) repository.TokenRevocationRepository {
+	return &TokenRevocationCache{
+		repo:   repo,
+		ttl:    ttl,
+		tokens: make(map[string]tokenEntry),
+		users:  make(map[uint]userEntry),
+	}
+}
+
+func (c *TokenRevocationCache) RevokeToken(token *entity.RevokedToken) error {
+	if err := c.repo.RevokeToken(token); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweepLocked()
+	// 失効は取り消されないため、トークンの有効期限まで保持してよい
+	c.tokens[token.JTI] = tokenEntry{revoked: true, expiresAt: token.ExpiresAt}
+	return nil
+}
+
+func (c *TokenRevocationCache) IsTokenRevoked(jti string) (bool, error) {
+	now := time.Now()
+	c.mu.RLock()
+	entry, ok := c.tokens[jti]
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := c.repo.IsTokenRevoked(jti)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweepLocked()
+	c.tokens[jti] = tokenEntry{revoked: revoked, expiresAt: now.Add(c.ttl)}
+	return revoked, nil
+}
+
+func (c *TokenRevocationCache) RevokeAllForUser(userID uint) (uint64, error) {
+	generation, err := c.repo.RevokeAllForUser(userID)
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setUserLocked(userID, generation, time.Now())
+	return generation, nil
+}
+
+func (c *TokenRevocationCache) GetUserTokenGeneration(userID uint) (uint64, error) {
+	now := time.Now()
+	c.mu.RLock()
+	entry, ok := c.users[userID]
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.generation, nil
+	}
+
+	generation, err := c.repo.GetUserTokenGeneration(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setUserLocked(userID, generation, now), nil
+}
+
+// DeleteExpired キャッシュのエントリはトークンの有効期限までに消えるため、リポジトリに委ねるだけでよい
+func (c *TokenRevocationCache) DeleteExpired(before time.Time) (int64, error) {
+	return c.repo.DeleteExpired(before)
+}
+
+// setUserLocked 世代をキャッシュし、キャッシュした世代を返す（ロック取得済みで呼ぶこと）
+// 世代は増える一方のため、照会中に一括失効が行われた場合でも古い世代で上書きしない。
+func (c *TokenRevocationCache) setUserLocked(userID uint, generation uint64, now time.Time) uint64 {
+	c.sweepLocked()
+	if entry, ok := c.users[userID]; ok && entry.generation > generation {
+		generation = entry.generation
+	}
+	c.users[userID] = userEntry{generation: generation, expiresAt: now.Add(c.ttl)}
+	return generation
+}
+
+// sweepLocked 件数が上限を超えている場合に期限切れのエントリを削除する（ロック取得済みで呼ぶこと）
+func (c *TokenRevocationCache) sweepLocked() {
+	if len(c.tokens)+len(c.users) < maxEntries {
+		return
+	}
+	now := time.Now()
+	for jti, entry := range c.tokens {
+		if !now.Before(entry.expiresAt) {
+			delete(c.tokens, jti)
+		}
+	}
+	for userID, entry := range c.users {
+		if !now.Before(entry.expiresAt) {
+			delete(c.users, userID)
+		}
+	}
+	if len(c.tokens)+len(c.users) >= maxEntries {
+		clear(c.tokens)
+		clear(c.users)
+	}
+}
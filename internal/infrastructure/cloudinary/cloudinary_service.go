@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 )
 
@@ -24,6 +26,13 @@ type UploadResult struct {
 	Bytes     int    `json:"bytes"`
 }
 
+// AssetInfo アップロード済みの画像の情報
+type AssetInfo struct {
+	PublicID  string    `json:"public_id"`
+	Bytes     int       `json:"bytes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 func NewCloudinaryService(cloudName, apiKey, apiSecret string) (*CloudinaryService, error) {
 	cld, err := cloudinary.NewFromParams(cloudName, apiKey, apiSecret)
 	if err != nil {
@@ -90,22 +99,59 @@ func (s *CloudinaryService) UploadImageFromFile(ctx context.Context, filePath st
 }
 
 func (s *CloudinaryService) DeleteImage(ctx context.Context, publicID string) error {
-	_, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+	result, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
 		PublicID:     publicID,
 		ResourceType: "image",
 	})
 	if err != nil {
 		return fmt.Errorf("画像の削除に失敗しました: %w", err)
 	}
+	// APIのエラーは err ではなく結果に入る（既に存在しない "not found" は削除済みとして扱う）
+	if result.Error.Message != "" {
+		return fmt.Errorf("画像の削除に失敗しました: %s", result.Error.Message)
+	}
 	return nil
 }
 
-// GeneratePublicID 一意のPublicIDを生成
-func GeneratePublicID(reviewID uint, timestamp int64, index int) string {
-	return fmt.Sprintf("review_%d_%d_%d", reviewID, timestamp, index)
+// ImageURL PublicIDから配信用のURLを生成
+func (s *CloudinaryService) ImageURL(publicID string) (string, error) {
+	image, err := s.cld.Image(publicID)
+	if err != nil {
+		return "", fmt.Errorf("画像URLの生成に失敗しました: %w", err)
+	}
+	return image.String()
 }
 
-// GenerateFolderPath フォルダパスを生成
-func GenerateFolderPath() string {
-	return fmt.Sprintf("sidemenulab/reviews/%d", time.Now().Year())
+// ListImages PublicIDが prefix で始まる画像をすべて取得
+func (s *CloudinaryService) ListImages(ctx context.Context, prefix string) ([]*AssetInfo, error) {
+	var assets []*AssetInfo
+	cursor := ""
+	for {
+		result, err := s.cld.Admin.Assets(ctx, admin.AssetsParams{
+			AssetType:    api.Image,
+			DeliveryType: "upload",
+			Prefix:       prefix,
+			MaxResults:   500,
+			NextCursor:   cursor,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("画像一覧の取得に失敗しました: %w", err)
+		}
+		if result.Error.Message != "" {
+			return nil, fmt.Errorf("画像一覧の取得に失敗しました: %s", result.Error.Message)
+		}
+
+		for _, asset := range result.Assets {
+			assets = append(assets, &AssetInfo{
+				PublicID:  asset.PublicID,
+				Bytes:     asset.Bytes,
+				CreatedAt: asset.CreatedAt,
+			})
+		}
+
+		if result.NextCursor == "" {
+			return assets, nil
+		}
+		cursor = result.NextCursor
+	}
 }
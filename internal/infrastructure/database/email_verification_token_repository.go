@@ -0,0 +1,68 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) repository.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{db: db}
+}
+
+func (r *emailVerificationTokenRepository) Create(token *entity.EmailVerificationToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *emailVerificationTokenRepository) GetByTokenHash(tokenHash string) (*entity.EmailVerificationToken, error) {
+	var token entity.EmailVerificationToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *emailVerificationTokenRepository) GetLatestByUserID(userID uint) (*entity.EmailVerificationToken, error) {
+	var token entity.EmailVerificationToken
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *emailVerificationTokenRepository) CountCreatedSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.EmailVerificationToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *emailVerificationTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&entity.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *emailVerificationTokenRepository) InvalidateByUserID(userID uint, usedAt time.Time) error {
+	return r.db.Model(&entity.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", usedAt).Error
+}
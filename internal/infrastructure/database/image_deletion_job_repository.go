@@ -0,0 +1,41 @@
+package database
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type ImageDeletionJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImageDeletionJobRepository(db *gorm.DB) repository.ImageDeletionJobRepository {
+	return &ImageDeletionJobRepository{db: db}
+}
+
+func (r *ImageDeletionJobRepository) GetDueImageDeletionJobs(provider string, now time.Time, limit int) ([]*entity.ImageDeletionJob, error) {
+	var jobs []*entity.ImageDeletionJob
+	if err := r.db.Where("storage_provider = ? AND next_attempt_at <= ? AND attempts < ?", provider, now, entity.MaxImageDeletionAttempts).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *ImageDeletionJobRepository) GetQueuedImageKeys(provider string, keys []string) (map[string]bool, error) {
+	return pluckExistingKeys(r.db.Model(&entity.ImageDeletionJob{}).Where("storage_provider = ?", provider), keys)
+}
+
+func (r *ImageDeletionJobRepository) UpdateImageDeletionJob(job *entity.ImageDeletionJob) error {
+	return r.db.Model(job).Select("attempts", "next_attempt_at", "last_error").Updates(job).Error
+}
+
+func (r *ImageDeletionJobRepository) DeleteImageDeletionJob(id uint) error {
+	return r.db.Delete(&entity.ImageDeletionJob{}, id).Error
+}
@@ -0,0 +1,359 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+// Migrate テーブル定義の反映と、AutoMigrate では表現できない拡張機能・インデックス・データ移行を行う
+func Migrate(db *gorm.DB) error {
+	// 全文検索の部分一致に pg_trgm を使う
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return fmt.Errorf("pg_trgm 拡張の有効化に失敗しました: %w", err)
+	}
+
+	// 一意制約を追加する前に重複したいいねを取り除き、いいね数の列が新しく追加される場合は後で集計する
+	if err := dedupReviewLikes(db); err != nil {
+		return err
+	}
+	backfillLikeCount := !db.Migrator().HasColumn(&entity.SideMenuReview{}, "like_count")
+	// 検索用テキストは保存時に設定されるため、列が新しく追加される場合だけ既存のレビューを埋める
+	backfillSearchText := !db.Migrator().HasColumn(&entity.SideMenuReview{}, "search_text")
+	// メールアドレスの確認が導入される前からのユーザーは、確認済みとして扱う
+	backfillEmailVerified := db.Migrator().HasTable(&entity.User{}) && !db.Migrator().HasColumn(&entity.User{}, "email_verified_at")
+	// 保存先のキーは列が新しく追加される場合だけ、それ以前にアップロードされた画像に設定する
+	backfillStorageKeys := db.Migrator().HasTable(&entity.SideMenuReviewImage{}) && !db.Migrator().HasColumn(&entity.SideMenuReviewImage{}, "storage_key")
+	if err := prepareUserHandles(db); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(
+		&entity.User{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.PasswordResetToken{},
+		&entity.EmailVerificationToken{},
+		&entity.Store{},
+		&entity.StoreAlias{},
+		&entity.SideMenu{},
+		&entity.SideMenuReview{},
+		&entity.SideMenuReviewImage{},
+		&entity.ImageDeletionJob{},
+		&entity.SideMenuReviewLike{},
+		&entity.ReviewComment{},
+		&entity.CommentMention{},
+		&entity.Reaction{},
+		&entity.Notification{},
+		&entity.NotificationPreference{},
+		&entity.RatingStats{},
+		&entity.TrendingGeneration{},
+		&entity.TrendingScoreChange{},
+	); err != nil {
+		return err
+	}
+
+	// キーセットページング用の (…, created_at, id) のインデックスに置き換えたもの
+	for _, index := range []string{"idx_notifications_user_created", "idx_side_menu_reviews_store_id", "idx_side_menu_reviews_side_menu_id"} {
+		if err := db.Exec("DROP INDEX IF EXISTS " + index).Error; err != nil {
+			return fmt.Errorf("インデックス %s の削除に失敗しました: %w", index, err)
+		}
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_side_menu_reviews_search_text ON side_menu_reviews USING gin (search_text gin_trgm_ops)").Error; err != nil {
+		return fmt.Errorf("検索用インデックスの作成に失敗しました: %w", err)
+	}
+
+	if backfillLikeCount {
+		if err := backfillReviewLikeCounts(db); err != nil {
+			return err
+		}
+	}
+	if backfillEmailVerified {
+		if err := backfillUserEmailVerified(db); err != nil {
+			return err
+		}
+	}
+
+	if backfillSearchText {
+		if err := backfillReviewSearchText(db); err != nil {
+			return err
+		}
+	}
+	if backfillStorageKeys {
+		if err := backfillReviewImageStorageKeys(db); err != nil {
+			return err
+		}
+	}
+	if err := backfillReviewStores(db); err != nil {
+		return err
+	}
+	if err := backfillReviewSideMenus(db); err != nil {
+		return err
+	}
+	return backfillRatingStats(db)
+}
+
+// backfillReviewSearchText 検索用テキストが未設定のレビューを埋める
+// 正規化はアプリケーション側で行うため、SQLではなく1件ずつ計算して更新する。
+func backfillReviewSearchText(db *gorm.DB) error {
+	var reviews []*entity.SideMenuReview
+	updated := 0
+	result := db.Unscoped().Where("search_text = ''").FindInBatches(&reviews, 500, func(_ *gorm.DB, batch int) error {
+		for _, review := range reviews {
+			if err := updateReviewSearchText(db, review, nil); err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return fmt.Errorf("検索用テキストの移行に失敗しました: %w", result.Error)
+	}
+	if updated > 0 {
+		log.Printf("%d件のレビューの検索用テキストを設定しました", updated)
+	}
+	return nil
+}
+
+// legacyCloudinaryFolder 保存先のキーを記録するようになる前に、Cloudinaryへのアップロードで使っていたフォルダ
+const legacyCloudinaryFolder = "sidemenulab/reviews/"
+
+// backfillReviewImageStorageKeys 保存先のキーを記録する前にCloudinaryへアップロードした画像に、URLから保存先とキーを設定する
+// キーがない画像は削除ジョブの対象にならず、レビューや画像を削除しても保存先に残り続けるため。
+// 画像のURLは利用者が自由に登録できるため、他人の画像を削除させられないよう、
+// アップロード時にそのレビュー用に生成した名前を指し、かつ1件の画像だけが参照しているURLに限って設定する。
+func backfillReviewImageStorageKeys(db *gorm.DB) error {
+	var images []*entity.SideMenuReviewImage
+	if err := db.Select("id", "review_id", "image_url").
+		Where("image_url LIKE ?", "%res.cloudinary.com/%/upload/%"+legacyCloudinaryFolder+"%").
+		Find(&images).Error; err != nil {
+		return fmt.Errorf("画像の保存先の移行に失敗しました: %w", err)
+	}
+
+	keys := make(map[uint]string, len(images))
+	references := make(map[string]int)
+	for _, image := range images {
+		key, ok := cloudinaryPublicID(image.ImageURL)
+		if !ok {
+			continue
+		}
+		keys[image.ID] = key
+		references[key]++
+	}
+
+	updated := 0
+	for _, image := range images {
+		key, ok := keys[image.ID]
+		if !ok || references[key] > 1 {
+			continue
+		}
+		if reviewID, ok := legacyImageReviewID(key); !ok || reviewID != image.ReviewID {
+			continue
+		}
+		if err := db.Model(image).UpdateColumns(map[string]interface{}{
+			"storage_provider": entity.StorageProviderCloudinary,
+			"storage_key":      key,
+		}).Error; err != nil {
+			return fmt.Errorf("画像の保存先の移行に失敗しました: %w", err)
+		}
+		updated++
+	}
+	if updated > 0 {
+		log.Printf("%d件の画像にCloudinaryの保存先を設定しました", updated)
+	}
+	return nil
+}
+
+// legacyImageName アップロード時に生成していた画像の名前（<フォルダ>/<年>/review_<レビューID>_<時刻>_<順番>）
+var legacyImageName = regexp.MustCompile(`^` + regexp.QuoteMeta(legacyCloudinaryFolder) + `\d{4}/review_(\d+)_\d+_\d+$`)
+
+// legacyImageReviewID アップロード時に生成した名前から、画像を生成したレビューのIDを取り出す
+func legacyImageReviewID(publicID string) (uint, bool) {
+	match := legacyImageName.FindStringSubmatch(publicID)
+	if match == nil {
+		return 0, false
+	}
+	reviewID, err := strconv.ParseUint(match[1], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(reviewID), true
+}
+
+// cloudinaryPublicID CloudinaryのURL（.../upload/[変換/][v123/]<PublicID>.<拡張子>）から legacyCloudinaryFolder 配下の PublicID を取り出す
+func cloudinaryPublicID(imageURL string) (string, bool) {
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Host != "res.cloudinary.com" {
+		return "", false
+	}
+	_, rest, found := strings.Cut(u.Path, "/upload/")
+	if !found {
+		return "", false
+	}
+	index := strings.Index("/"+rest, "/"+legacyCloudinaryFolder)
+	if index < 0 {
+		return "", false
+	}
+
+	publicID := rest[index:]
+	if dot := strings.LastIndex(publicID, "."); dot > strings.LastIndex(publicID, "/") {
+		publicID = publicID[:dot]
+	}
+	if strings.HasSuffix(publicID, "/") || publicID == strings.TrimSuffix(legacyCloudinaryFolder, "/") {
+		return "", false
+	}
+	return publicID, true
+}
+
+// backfillReviewStores 店舗に紐付いていないレビューを、店舗名から名寄せ（なければ作成）した店舗に紐付ける
+// 旧クライアント向けに store_name はそのまま残す。
+func backfillReviewStores(db *gorm.DB) error {
+	var names []string
+	if err := db.Unscoped().Model(&entity.SideMenuReview{}).Where("store_id IS NULL").Distinct().Pluck("store_name", &names).Error; err != nil {
+		return fmt.Errorf("店舗名の取得に失敗しました: %w", err)
+	}
+
+	var linked int64
+	for _, name := range names {
+		if entity.NormalizeStoreName(name) == "" {
+			log.Printf("店舗名「%s」は店舗として登録できないため、紐付けをスキップします", name)
+			continue
+		}
+		store, err := findOrCreateStoreByName(db, name)
+		if err != nil {
+			return fmt.Errorf("店舗「%s」の登録に失敗しました: %w", name, err)
+		}
+		result := db.Unscoped().Model(&entity.SideMenuReview{}).
+			Where("store_id IS NULL AND store_name = ?", name).
+			UpdateColumn("store_id", store.ID)
+		if result.Error != nil {
+			return fmt.Errorf("レビューと店舗「%s」の紐付けに失敗しました: %w", name, result.Error)
+		}
+		linked += result.RowsAffected
+	}
+	if linked > 0 {
+		log.Printf("%d件のレビューを店舗に紐付けました", linked)
+	}
+	return nil
+}
+
+// backfillReviewSideMenus 店舗に紐付いたレビューのうち、サイドメニューに紐付いていないものをメニュー名から名寄せ（なければ作成）して紐付ける
+// 店舗に紐付けられなかったレビューは対象外とする。
+func backfillReviewSideMenus(db *gorm.DB) error {
+	type storeMenu struct {
+		StoreID      uint
+		SideMenuName string
+	}
+	var pairs []storeMenu
+	if err := db.Unscoped().Model(&entity.SideMenuReview{}).
+		Where("side_menu_id IS NULL AND store_id IS NOT NULL").
+		Distinct("store_id", "side_menu_name").
+		Find(&pairs).Error; err != nil {
+		return fmt.Errorf("サイドメニュー名の取得に失敗しました: %w", err)
+	}
+
+	var linked int64
+	for _, pair := range pairs {
+		if entity.NormalizeStoreName(pair.SideMenuName) == "" {
+			log.Printf("メニュー名「%s」はサイドメニューとして登録できないため、紐付けをスキップします", pair.SideMenuName)
+			continue
+		}
+		menu, err := findOrCreateSideMenuByName(db, pair.StoreID, pair.SideMenuName)
+		if err != nil {
+			return fmt.Errorf("サイドメニュー「%s」の登録に失敗しました: %w", pair.SideMenuName, err)
+		}
+		result := db.Unscoped().Model(&entity.SideMenuReview{}).
+			Where("side_menu_id IS NULL AND store_id = ? AND side_menu_name = ?", pair.StoreID, pair.SideMenuName).
+			UpdateColumn("side_menu_id", menu.ID)
+		if result.Error != nil {
+			return fmt.Errorf("レビューとサイドメニュー「%s」の紐付けに失敗しました: %w", pair.SideMenuName, result.Error)
+		}
+		linked += result.RowsAffected
+	}
+	if linked > 0 {
+		log.Printf("%d件のレビューをサイドメニューに紐付けました", linked)
+	}
+	return nil
+}
+
+// backfillRatingStats 評価の集計がまだない場合に、既存のレビューから作成する
+// 店舗・サイドメニューへの紐付けが終わってから実行する。
+func backfillRatingStats(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&entity.RatingStats{}).Where("subject_type = ?", entity.RatingSubjectOverall).Count(&count).Error; err != nil {
+		return fmt.Errorf("評価の集計の確認に失敗しました: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	return rebuildRatingStats(db)
+}
+
+// dedupReviewLikes 同じユーザーによる同じレビューへの重複したいいねを、最初の1件を残して削除する
+func dedupReviewLikes(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&entity.SideMenuReviewLike{}) {
+		return nil
+	}
+
+	result := db.Exec("DELETE FROM side_menu_review_likes AS duplicate USING side_menu_review_likes AS original " +
+		"WHERE duplicate.review_id = original.review_id AND duplicate.user_id = original.user_id AND duplicate.id > original.id")
+	if result.Error != nil {
+		return fmt.Errorf("重複したいいねの削除に失敗しました: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("重複したいいねを%d件削除しました", result.RowsAffected)
+	}
+	return nil
+}
+
+// prepareUserHandles 既存のユーザーに仮のハンドル（user + ID）を割り当てる
+// ハンドルの列は NOT NULL かつ一意のため、AutoMigrate で制約を付ける前に値を埋めておく。
+func prepareUserHandles(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&entity.User{}) || db.Migrator().HasColumn(&entity.User{}, "handle") {
+		return nil
+	}
+
+	if err := db.Exec("ALTER TABLE users ADD COLUMN handle varchar(30)").Error; err != nil {
+		return fmt.Errorf("ハンドル列の追加に失敗しました: %w", err)
+	}
+	result := db.Exec("UPDATE users SET handle = 'user' || id WHERE handle IS NULL")
+	if result.Error != nil {
+		return fmt.Errorf("既存ユーザーへのハンドルの割り当てに失敗しました: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("既存ユーザー%d件にハンドルを割り当てました", result.RowsAffected)
+	}
+	return nil
+}
+
+// backfillUserEmailVerified 既存のユーザーのメールアドレスを確認済みにする
+// REQUIRE_EMAIL_VERIFICATION を有効にしたときに、既存のユーザーが投稿できなくならないようにする。
+func backfillUserEmailVerified(db *gorm.DB) error {
+	result := db.Exec("UPDATE users SET email_verified_at = NOW() WHERE email_verified_at IS NULL")
+	if result.Error != nil {
+		return fmt.Errorf("既存ユーザーのメールアドレスの確認状態の移行に失敗しました: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("既存ユーザー%d件のメールアドレスを確認済みにしました", result.RowsAffected)
+	}
+	return nil
+}
+
+// backfillReviewLikeCounts 既存のいいねからレビューのいいね数を集計する
+func backfillReviewLikeCounts(db *gorm.DB) error {
+	if err := db.Exec("UPDATE side_menu_reviews SET like_count = likes.count " +
+		"FROM (SELECT review_id, COUNT(*) AS count FROM side_menu_review_likes GROUP BY review_id) AS likes " +
+		"WHERE likes.review_id = side_menu_reviews.id").Error; err != nil {
+		return fmt.Errorf("いいね数の集計に失敗しました: %w", err)
+	}
+	return nil
+}
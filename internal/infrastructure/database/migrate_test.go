@@ -0,0 +1,86 @@
+package database
+
+import "testing"
+
+func TestCloudinaryPublicID(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+		ok   bool
+	}{
+		{
+			name: "バージョン付き",
+			url:  "https://res.cloudinary.com/demo/image/upload/v1729600000/sidemenulab/reviews/2025/review_1_1729600000_0.jpg",
+			want: "sidemenulab/reviews/2025/review_1_1729600000_0",
+			ok:   true,
+		},
+		{
+			name: "変換とバージョン付き",
+			url:  "https://res.cloudinary.com/demo/image/upload/c_fill,w_300/v1729600000/sidemenulab/reviews/2024/review_2_1700000000_1.webp",
+			want: "sidemenulab/reviews/2024/review_2_1700000000_1",
+			ok:   true,
+		},
+		{
+			name: "バージョンなし・拡張子なし",
+			url:  "https://res.cloudinary.com/demo/image/upload/sidemenulab/reviews/2025/review_3_1729600000_0",
+			want: "sidemenulab/reviews/2025/review_3_1729600000_0",
+			ok:   true,
+		},
+		{
+			name: "別のフォルダ",
+			url:  "https://res.cloudinary.com/demo/image/upload/v1/other/review_1.jpg",
+		},
+		{
+			name: "フォルダ名を含むだけのパス",
+			url:  "https://res.cloudinary.com/demo/image/upload/v1/xsidemenulab/reviews/2025/review_1.jpg",
+		},
+		{
+			name: "Cloudinary以外のURL",
+			url:  "https://example.com/image/upload/v1/sidemenulab/reviews/2025/review_1.jpg",
+		},
+		{
+			name: "ローカルの画像",
+			url:  "http://localhost:8080/uploads/sidemenulab/reviews/2025/review_1_1729600000_0.jpg",
+		},
+		{
+			name: "フォルダのみ",
+			url:  "https://res.cloudinary.com/demo/image/upload/v1/sidemenulab/reviews/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cloudinaryPublicID(tt.url)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("cloudinaryPublicID(%q) = (%q, %v), want (%q, %v)", tt.url, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLegacyImageReviewID(t *testing.T) {
+	tests := []struct {
+		name     string
+		publicID string
+		want     uint
+		ok       bool
+	}{
+		{name: "アップロード時に生成した名前", publicID: "sidemenulab/reviews/2025/review_12_1729600000123456789_0", want: 12, ok: true},
+		{name: "2枚目以降", publicID: "sidemenulab/reviews/2024/review_3_1700000000_4", want: 3, ok: true},
+		{name: "生成した名前ではない", publicID: "sidemenulab/reviews/2025/my_photo"},
+		{name: "年のフォルダがない", publicID: "sidemenulab/reviews/review_12_1729600000_0"},
+		{name: "さらに下のフォルダ", publicID: "sidemenulab/reviews/2025/x/review_12_1729600000_0"},
+		{name: "別のフォルダ", publicID: "other/2025/review_12_1729600000_0"},
+		{name: "レビューIDが範囲外", publicID: "sidemenulab/reviews/2025/review_99999999999_1729600000_0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := legacyImageReviewID(tt.publicID)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("legacyImageReviewID(%q) = (%d, %v), want (%d, %v)", tt.publicID, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) CreateNotification(notification *entity.Notification) error {
+	return r.db.Omit(clause.Associations).Create(notification).Error
+}
+
+func (r *NotificationRepository) ExistsSince(notification *entity.Notification, since time.Time) (bool, error) {
+	query := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND type = ? AND created_at >= ?", notification.UserID, notification.Type, since)
+	for _, target := range []struct {
+		column string
+		value  *uint
+	}{
+		{"actor_id", notification.ActorID},
+		{"review_id", notification.ReviewID},
+		{"comment_id", notification.CommentID},
+	} {
+		if target.value == nil {
+			query = query.Where(target.column + " IS NULL")
+		} else {
+			query = query.Where(target.column+" = ?", *target.value)
+		}
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *NotificationRepository) GetNotificationsByUserID(userID uint, unreadOnly bool, page entity.PageRequest) ([]*entity.Notification, *entity.PageInfo, error) {
+	var notifications []*entity.Notification
+	query := r.db.Preload("Actor").Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := paginate(query, "notifications", page).Find(&notifications).Error; err != nil {
+		return nil, nil, err
+	}
+	notifications, info := buildPage(notifications, page, notificationKey)
+	return notifications, info, nil
+}
+
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *NotificationRepository) MarkRead(userID uint, id uint) (bool, error) {
+	var notification entity.Notification
+	if err := r.db.Select("id", "read_at").Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if notification.ReadAt != nil {
+		return true, nil
+	}
+	return true, r.db.Model(&notification).UpdateColumn("read_at", time.Now()).Error
+}
+
+func (r *NotificationRepository) MarkAllRead(userID uint) (int64, error) {
+	result := r.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).UpdateColumn("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func (r *NotificationRepository) GetPreferences(userID uint) (entity.NotificationPreferences, error) {
+	var rows []*entity.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	prefs := make(entity.NotificationPreferences, len(rows))
+	for _, row := range rows {
+		prefs[row.Type] = row.Enabled
+	}
+	return prefs, nil
+}
+
+func (r *NotificationRepository) SavePreferences(userID uint, prefs entity.NotificationPreferences) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	rows := make([]*entity.NotificationPreference, 0, len(prefs))
+	for t, enabled := range prefs {
+		rows = append(rows, &entity.NotificationPreference{UserID: userID, Type: t, Enabled: enabled})
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&rows).Error
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"sidemenulab-backend/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+// paginate (created_at, id) のキーセット条件と並び順を付与する
+// 次ページの有無を判定するため limit+1 件を取得する。
+func paginate(query *gorm.DB, table string, page entity.PageRequest) *gorm.DB {
+	return paginateBy(query, "", table, page)
+}
+
+// paginateBy sortExpr の値を先頭キーとして (sortExpr, created_at, id) の降順でページングする
+// sortExpr が空の場合は (created_at, id) のみを使う。
+func paginateBy(query *gorm.DB, sortExpr string, table string, page entity.PageRequest) *gorm.DB {
+	createdAt := table + ".created_at"
+	id := table + ".id"
+
+	columns := createdAt + ", " + id
+	orders := []string{createdAt, id}
+	var values []interface{}
+	if cursor := page.Cursor; cursor != nil {
+		values = []interface{}{cursor.CreatedAt, cursor.ID}
+	}
+	if sortExpr != "" {
+		columns = sortExpr + ", " + columns
+		orders = append([]string{sortExpr}, orders...)
+		if page.Cursor != nil {
+			values = append([]interface{}{page.Cursor.Value}, values...)
+		}
+	}
+
+	placeholders := "?"
+	for i := 1; i < len(orders); i++ {
+		placeholders += ", ?"
+	}
+
+	direction := " DESC"
+	switch cursor := page.Cursor; {
+	case cursor == nil:
+	case cursor.Backward:
+		// 前のページは昇順で取得し、buildPage で降順に戻す
+		query = query.Where("("+columns+") > ("+placeholders+")", values...)
+		direction = " ASC"
+	default:
+		query = query.Where("("+columns+") < ("+placeholders+")", values...)
+	}
+	for _, order := range orders {
+		query = query.Order(order + direction)
+	}
+
+	return query.Limit(page.Limit + 1)
+}
+
+// buildPage paginate で取得した結果から表示分を切り出し、前後のカーソルを組み立てる
+// key は各要素の位置をカーソルとして返す（Backward は buildPage が設定する）。
+func buildPage[T any](items []T, page entity.PageRequest, key func(T) entity.Cursor) ([]T, *entity.PageInfo) {
+	hasMore := len(items) > page.Limit
+	if hasMore {
+		items = items[:page.Limit]
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	info := &entity.PageInfo{HasMore: hasMore}
+	if len(items) == 0 {
+		return items, info
+	}
+
+	prev := key(items[0])
+	prev.Backward = true
+	next := key(items[len(items)-1])
+
+	if backward {
+		// 後ろ側には必ずカーソルの元になったページがある
+		info.NextCursor = next.Encode()
+		if hasMore {
+			info.PrevCursor = prev.Encode()
+		}
+	} else {
+		if hasMore {
+			info.NextCursor = next.Encode()
+		}
+		if page.Cursor != nil {
+			info.PrevCursor = prev.Encode()
+		}
+	}
+	return items, info
+}
+
+func reviewKey(r *entity.SideMenuReview) entity.Cursor {
+	return entity.Cursor{CreatedAt: r.CreatedAt, ID: r.ID}
+}
+
+func reviewLikeKey(l *entity.SideMenuReviewLike) entity.Cursor {
+	return entity.Cursor{CreatedAt: l.CreatedAt, ID: l.ID}
+}
+
+func reviewCommentKey(c *entity.ReviewComment) entity.Cursor {
+	return entity.Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+func notificationKey(n *entity.Notification) entity.Cursor {
+	return entity.Cursor{CreatedAt: n.CreatedAt, ID: n.ID}
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) repository.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+func (r *passwordResetTokenRepository) Create(token *entity.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *passwordResetTokenRepository) GetByTokenHash(tokenHash string) (*entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) GetLatestByUserID(userID uint) (*entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) CountCreatedSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *passwordResetTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&entity.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *passwordResetTokenRepository) InvalidateByUserID(userID uint, usedAt time.Time) error {
+	return r.db.Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", usedAt).Error
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type rankingRepository struct {
+	db *gorm.DB
+}
+
+func NewRankingRepository(db *gorm.DB) repository.RankingRepository {
+	return &rankingRepository{db: db}
+}
+
+// rankingRow 順位付けの結果（ID はサイドメニューまたは店舗のID）
+type rankingRow struct {
+	ID             uint
+	ReviewCount    int64
+	RatingSum      int64
+	LikeCount      int64
+	BayesianRating float64
+}
+
+func (row *rankingRow) score() entity.RankingScore {
+	score := entity.RankingScore{
+		ReviewCount:    row.ReviewCount,
+		BayesianRating: row.BayesianRating,
+		LikeCount:      row.LikeCount,
+	}
+	if row.ReviewCount > 0 {
+		score.AverageRating = float64(row.RatingSum) / float64(row.ReviewCount)
+	}
+	return score
+}
+
+// bayesianRatingExpr 評価の合計と件数の式からベイズ平均を求める式
+// プレースホルダには重み・事前平均・重みの順に値を渡す。
+func bayesianRatingExpr(sum, count string) string {
+	return "(?::float8 * ?::float8 + " + sum + ") / (?::float8 + " + count + ")"
+}
+
+// likeCountExpr 指定した条件に合う、削除されていないレビューのいいね数の合計を求める式
+func likeCountExpr(condition string) string {
+	return "(SELECT COALESCE(SUM(side_menu_reviews.like_count), 0) FROM side_menu_reviews " +
+		"WHERE side_menu_reviews." + condition + " AND side_menu_reviews.deleted_at IS NULL)"
+}
+
+const rankingOrder = "bayesian_rating DESC, like_count DESC, id"
+
+func (r *rankingRepository) RankSideMenus(params entity.RankingParams) ([]*entity.SideMenuRanking, error) {
+	// 評価は rating_stats に集計済みのため、いいね数だけをレビューから数える
+	query := r.db.Table("side_menus").
+		Select("side_menus.id, rating_stats.review_count, rating_stats.rating_sum, "+
+			likeCountExpr("side_menu_id = side_menus.id")+" AS like_count, "+
+			bayesianRatingExpr("rating_stats.rating_sum", "rating_stats.review_count")+" AS bayesian_rating",
+			entity.RatingPriorWeight, params.PriorMean, entity.RatingPriorWeight).
+		Joins("JOIN rating_stats ON rating_stats.subject_type = ? AND rating_stats.subject_id = side_menus.id", entity.RatingSubjectSideMenu).
+		Where("rating_stats.review_count >= ?", params.MinReviews)
+	if params.Category != "" {
+		query = query.Where("side_menus.category = ?", params.Category)
+	}
+
+	var rows []*rankingRow
+	if err := query.Order(rankingOrder).Limit(params.Limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var menus []*entity.SideMenu
+	if err := r.db.Preload("Store").Where("id IN ?", rankingIDs(rows)).Find(&menus).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*entity.SideMenu, len(menus))
+	for _, menu := range menus {
+		byID[menu.ID] = menu
+	}
+
+	rankings := make([]*entity.SideMenuRanking, 0, len(rows))
+	for _, row := range rows {
+		if menu, ok := byID[row.ID]; ok {
+			rankings = append(rankings, &entity.SideMenuRanking{Rank: len(rankings) + 1, SideMenu: menu, RankingScore: row.score()})
+		}
+	}
+	return rankings, nil
+}
+
+func (r *rankingRepository) RankStores(params entity.RankingParams) ([]*entity.StoreRanking, error) {
+	var query *gorm.DB
+	if params.Category == "" {
+		query = r.db.Table("stores").
+			Select("stores.id, rating_stats.review_count, rating_stats.rating_sum, "+
+				likeCountExpr("store_id = stores.id")+" AS like_count, "+
+				bayesianRatingExpr("rating_stats.rating_sum", "rating_stats.review_count")+" AS bayesian_rating",
+				entity.RatingPriorWeight, params.PriorMean, entity.RatingPriorWeight).
+			Joins("JOIN rating_stats ON rating_stats.subject_type = ? AND rating_stats.subject_id = stores.id", entity.RatingSubjectStore).
+			Where("rating_stats.review_count >= ?", params.MinReviews)
+	} else {
+		// カテゴリ別の集計は保持していないため、そのカテゴリのサイドメニューへのレビューから集計する
+		query = r.db.Table("side_menu_reviews").
+			Select("side_menu_reviews.store_id AS id, COUNT(*) AS review_count, SUM(side_menu_reviews.rating) AS rating_sum, "+
+				"SUM(side_menu_reviews.like_count) AS like_count, "+
+				bayesianRatingExpr("SUM(side_menu_reviews.rating)", "COUNT(*)")+" AS bayesian_rating",
+				entity.RatingPriorWeight, params.PriorMean, entity.RatingPriorWeight).
+			Joins("JOIN side_menus ON side_menus.id = side_menu_reviews.side_menu_id").
+			Where("side_menu_reviews.deleted_at IS NULL AND side_menu_reviews.store_id IS NOT NULL AND side_menus.category = ?", params.Category).
+			Group("side_menu_reviews.store_id").
+			Having("COUNT(*) >= ?", params.MinReviews)
+	}
+
+	var rows []*rankingRow
+	if err := query.Order(rankingOrder).Limit(params.Limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var stores []*entity.Store
+	if err := r.db.Preload("Aliases").Where("id IN ?", rankingIDs(rows)).Find(&stores).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*entity.Store, len(stores))
+	for _, store := range stores {
+		byID[store.ID] = store
+	}
+
+	rankings := make([]*entity.StoreRanking, 0, len(rows))
+	for _, row := range rows {
+		if store, ok := byID[row.ID]; ok {
+			rankings = append(rankings, &entity.StoreRanking{Rank: len(rankings) + 1, Store: store, RankingScore: row.score()})
+		}
+	}
+	return rankings, nil
+}
+
+func rankingIDs(rows []*rankingRow) []uint {
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids
+}
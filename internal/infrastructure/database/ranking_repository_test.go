@@ -0,0 +1,73 @@
+package database
+
+import (
+	"testing"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+func TestRankingRowScore(t *testing.T) {
+	tests := []struct {
+		name string
+		row  rankingRow
+		want entity.RankingScore
+	}{
+		{
+			name: "平均評価を評価の合計と件数から求める",
+			row:  rankingRow{ID: 1, ReviewCount: 4, RatingSum: 15, LikeCount: 8, BayesianRating: 3.6},
+			want: entity.RankingScore{ReviewCount: 4, AverageRating: 3.75, BayesianRating: 3.6, LikeCount: 8},
+		},
+		{
+			name: "レビューがなければ平均評価は 0",
+			row:  rankingRow{ID: 2, BayesianRating: 3.5},
+			want: entity.RankingScore{BayesianRating: 3.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.row.score(); got != tt.want {
+				t.Errorf("score() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingSubjects(t *testing.T) {
+	storeID, sideMenuID := uint(3), uint(7)
+
+	tests := []struct {
+		name   string
+		review *entity.SideMenuReview
+		want   []entity.RatingStats
+	}{
+		{
+			name:   "店舗・サイドメニューに紐付いていないレビューは全体のみ",
+			review: &entity.SideMenuReview{Rating: 4},
+			want:   []entity.RatingStats{{SubjectType: entity.RatingSubjectOverall}},
+		},
+		{
+			name:   "店舗とサイドメニューに紐付いたレビュー",
+			review: &entity.SideMenuReview{Rating: 4, StoreID: &storeID, SideMenuID: &sideMenuID},
+			want: []entity.RatingStats{
+				{SubjectType: entity.RatingSubjectOverall},
+				{SubjectType: entity.RatingSubjectStore, SubjectID: storeID},
+				{SubjectType: entity.RatingSubjectSideMenu, SubjectID: sideMenuID},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ratingSubjects(tt.review)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ratingSubjects() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].SubjectType != tt.want[i].SubjectType || got[i].SubjectID != tt.want[i].SubjectID {
+					t.Errorf("ratingSubjects()[%d] = %s/%d, want %s/%d", i, got[i].SubjectType, got[i].SubjectID, tt.want[i].SubjectType, tt.want[i].SubjectID)
+				}
+			}
+		})
+	}
+}
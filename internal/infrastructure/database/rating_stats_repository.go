@@ -0,0 +1,116 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ratingStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewRatingStatsRepository(db *gorm.DB) repository.RatingStatsRepository {
+	return &ratingStatsRepository{db: db}
+}
+
+func (r *ratingStatsRepository) Get(subjectType string, subjectID uint) (*entity.RatingStats, error) {
+	stats := entity.RatingStats{SubjectType: subjectType, SubjectID: subjectID}
+	err := r.db.Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).First(&stats).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *ratingStatsRepository) Rebuild() error {
+	return rebuildRatingStats(r.db)
+}
+
+// ratingSubjects レビューが集計に寄与する対象（全体・店舗・サイドメニュー）
+func ratingSubjects(review *entity.SideMenuReview) []entity.RatingStats {
+	subjects := []entity.RatingStats{{SubjectType: entity.RatingSubjectOverall}}
+	if review.StoreID != nil {
+		subjects = append(subjects, entity.RatingStats{SubjectType: entity.RatingSubjectStore, SubjectID: *review.StoreID})
+	}
+	if review.SideMenuID != nil {
+		subjects = append(subjects, entity.RatingStats{SubjectType: entity.RatingSubjectSideMenu, SubjectID: *review.SideMenuID})
+	}
+	return subjects
+}
+
+// applyRatingDelta レビュー1件分の評価を集計に加える（sign が -1 の場合は取り除く）
+// 行がなければ作成し、あれば差分を加算するため、同時に書き込まれても集計がずれない。
+func applyRatingDelta(tx *gorm.DB, review *entity.SideMenuReview, sign int64) error {
+	if review.Rating < 1 || review.Rating > 5 {
+		return fmt.Errorf("評価 %d は集計できません", review.Rating)
+	}
+
+	now := time.Now()
+	for _, stats := range ratingSubjects(review) {
+		stats.ReviewCount = sign
+		stats.RatingSum = sign * int64(review.Rating)
+		switch review.Rating {
+		case 1:
+			stats.Star1 = sign
+		case 2:
+			stats.Star2 = sign
+		case 3:
+			stats.Star3 = sign
+		case 4:
+			stats.Star4 = sign
+		case 5:
+			stats.Star5 = sign
+		}
+		stats.UpdatedAt = now
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"review_count": gorm.Expr("rating_stats.review_count + EXCLUDED.review_count"),
+				"rating_sum":   gorm.Expr("rating_stats.rating_sum + EXCLUDED.rating_sum"),
+				"star1":        gorm.Expr("rating_stats.star1 + EXCLUDED.star1"),
+				"star2":        gorm.Expr("rating_stats.star2 + EXCLUDED.star2"),
+				"star3":        gorm.Expr("rating_stats.star3 + EXCLUDED.star3"),
+				"star4":        gorm.Expr("rating_stats.star4 + EXCLUDED.star4"),
+				"star5":        gorm.Expr("rating_stats.star5 + EXCLUDED.star5"),
+				"updated_at":   gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).Create(&stats).Error; err != nil {
+			return fmt.Errorf("評価の集計の更新に失敗しました: %w", err)
+		}
+	}
+	return nil
+}
+
+// ratingStatsColumns 集計を作り直す際に side_menu_reviews から算出する列
+const ratingStatsColumns = "COUNT(*), SUM(rating), " +
+	"COUNT(*) FILTER (WHERE rating = 1), COUNT(*) FILTER (WHERE rating = 2), COUNT(*) FILTER (WHERE rating = 3), " +
+	"COUNT(*) FILTER (WHERE rating = 4), COUNT(*) FILTER (WHERE rating = 5), NOW()"
+
+// rebuildRatingStats 削除されていないレビューから集計をすべて作り直す
+func rebuildRatingStats(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			"DELETE FROM rating_stats",
+			"INSERT INTO rating_stats (subject_type, subject_id, review_count, rating_sum, star1, star2, star3, star4, star5, updated_at) " +
+				"SELECT 'overall', 0, " + ratingStatsColumns + " FROM side_menu_reviews WHERE deleted_at IS NULL HAVING COUNT(*) > 0",
+			"INSERT INTO rating_stats (subject_type, subject_id, review_count, rating_sum, star1, star2, star3, star4, star5, updated_at) " +
+				"SELECT 'store', store_id, " + ratingStatsColumns + " FROM side_menu_reviews WHERE deleted_at IS NULL AND store_id IS NOT NULL GROUP BY store_id",
+			"INSERT INTO rating_stats (subject_type, subject_id, review_count, rating_sum, star1, star2, star3, star4, star5, updated_at) " +
+				"SELECT 'side_menu', side_menu_id, " + ratingStatsColumns + " FROM side_menu_reviews WHERE deleted_at IS NULL AND side_menu_id IS NOT NULL GROUP BY side_menu_id",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("評価の集計の再作成に失敗しました: %w", err)
+			}
+		}
+		return nil
+	})
+}
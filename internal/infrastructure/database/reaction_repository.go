@@ -0,0 +1,83 @@
+package database
+
+import (
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) repository.ReactionRepository {
+	return &ReactionRepository{db: db}
+}
+
+func (r *ReactionRepository) AddReaction(reaction *entity.Reaction) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "target_type"}, {Name: "target_id"}, {Name: "user_id"}, {Name: "type"}},
+		DoNothing: true,
+	}).Create(reaction)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ReactionRepository) RemoveReaction(targetType entity.ReactionTargetType, targetID uint, userID uint, reactionType entity.ReactionType) (bool, error) {
+	result := r.db.Where("target_type = ? AND target_id = ? AND user_id = ? AND type = ?", targetType, targetID, userID, reactionType).
+		Delete(&entity.Reaction{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ReactionRepository) CountReactions(targetType entity.ReactionTargetType, targetIDs []uint) (map[uint]entity.ReactionCounts, error) {
+	counts := make(map[uint]entity.ReactionCounts, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TargetID uint
+		Type     entity.ReactionType
+		Count    int64
+	}
+	if err := r.db.Model(&entity.Reaction{}).
+		Select("target_id, type, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Group("target_id, type").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if counts[row.TargetID] == nil {
+			counts[row.TargetID] = entity.ReactionCounts{}
+		}
+		counts[row.TargetID][row.Type] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *ReactionRepository) GetUserReactions(targetType entity.ReactionTargetType, targetIDs []uint, userID uint) (map[uint][]entity.ReactionType, error) {
+	reactions := make(map[uint][]entity.ReactionType, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return reactions, nil
+	}
+
+	var rows []*entity.Reaction
+	if err := r.db.Select("target_id", "type").
+		Where("target_type = ? AND target_id IN ? AND user_id = ?", targetType, targetIDs, userID).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		reactions[row.TargetID] = append(reactions[row.TargetID], row.Type)
+	}
+	return reactions, nil
+}
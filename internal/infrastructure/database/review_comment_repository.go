@@ -1,10 +1,13 @@
 package database
 
 import (
+	"errors"
+
 	"sidemenulab-backend/internal/domain/entity"
 	"sidemenulab-backend/internal/domain/repository"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReviewCommentRepository struct {
@@ -15,46 +18,192 @@ func NewReviewCommentRepository(db *gorm.DB) repository.ReviewCommentRepository
 	return &ReviewCommentRepository{db: db}
 }
 
+// withRelations レスポンスに含める関連データを読み込む
+func (r *ReviewCommentRepository) withRelations() *gorm.DB {
+	return r.db.Preload("Review").Preload("User").Preload("Mentions")
+}
+
+// CreateReviewComment 返信の場合は返信先をロックして検証し直してから作成する
+// 返信先の削除（DeleteReviewComment）も同じ行をロックするため、削除済みのコメントに返信が付くことはない。
 func (r *ReviewCommentRepository) CreateReviewComment(comment *entity.ReviewComment) error {
-	return r.db.Create(comment).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if comment.ParentID != nil {
+			var parent entity.ReviewComment
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "review_id", "root_id", "depth", "is_deleted").
+				First(&parent, *comment.ParentID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &entity.NotFoundError{Resource: "返信先のコメント"}
+			}
+			if err != nil {
+				return err
+			}
+			if err := comment.AttachParent(&parent); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return nil
+		}
+		return tx.Model(&entity.ReviewComment{}).Where("id = ?", *comment.ParentID).
+			UpdateColumn("reply_count", gorm.Expr("reply_count + 1")).Error
+	})
 }
 
 func (r *ReviewCommentRepository) GetReviewCommentByID(id uint) (*entity.ReviewComment, error) {
 	var comment entity.ReviewComment
-	if err := r.db.Preload("Review").Preload("User").First(&comment, id).Error; err != nil {
+	if err := r.withRelations().First(&comment, id).Error; err != nil {
 		return nil, err
 	}
 	return &comment, nil
 }
 
-func (r *ReviewCommentRepository) GetReviewCommentsByReviewID(reviewID uint) ([]*entity.ReviewComment, error) {
+func (r *ReviewCommentRepository) GetReviewCommentsByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
 	var comments []*entity.ReviewComment
-	if err := r.db.Preload("Review").Preload("User").Where("review_id = ?", reviewID).Order("created_at DESC").Find(&comments).Error; err != nil {
-		return nil, err
+	query := r.withRelations().Where("review_id = ?", reviewID)
+	if err := paginate(query, "review_comments", page).Find(&comments).Error; err != nil {
+		return nil, nil, err
 	}
-	return comments, nil
+	comments, info := buildPage(comments, page, reviewCommentKey)
+	return comments, info, nil
 }
 
-func (r *ReviewCommentRepository) GetReviewCommentsByUserID(userID uint) ([]*entity.ReviewComment, error) {
+func (r *ReviewCommentRepository) GetTopLevelReviewCommentsByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
 	var comments []*entity.ReviewComment
-	if err := r.db.Preload("Review").Preload("User").Where("user_id = ?", userID).Order("created_at DESC").Find(&comments).Error; err != nil {
+	query := r.withRelations().Where("review_id = ? AND parent_id IS NULL", reviewID)
+	if err := paginate(query, "review_comments", page).Find(&comments).Error; err != nil {
+		return nil, nil, err
+	}
+	comments, info := buildPage(comments, page, reviewCommentKey)
+	return comments, info, nil
+}
+
+func (r *ReviewCommentRepository) GetRepliesByRootIDs(rootIDs []uint) ([]*entity.ReviewComment, error) {
+	var replies []*entity.ReviewComment
+	if len(rootIDs) == 0 {
+		return replies, nil
+	}
+	if err := r.withRelations().
+		Where("root_id IN ?", rootIDs).
+		Order("created_at ASC").Order("id ASC").
+		Find(&replies).Error; err != nil {
 		return nil, err
 	}
-	return comments, nil
+	return replies, nil
 }
 
-func (r *ReviewCommentRepository) GetAllReviewComments() ([]*entity.ReviewComment, error) {
+func (r *ReviewCommentRepository) GetRepliesByParentID(parentID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
 	var comments []*entity.ReviewComment
-	if err := r.db.Preload("Review").Preload("User").Order("created_at DESC").Find(&comments).Error; err != nil {
-		return nil, err
+	query := r.withRelations().Where("parent_id = ?", parentID)
+	if err := paginate(query, "review_comments", page).Find(&comments).Error; err != nil {
+		return nil, nil, err
 	}
-	return comments, nil
+	comments, info := buildPage(comments, page, reviewCommentKey)
+	return comments, info, nil
 }
 
+func (r *ReviewCommentRepository) GetReviewCommentsByUserID(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	var comments []*entity.ReviewComment
+	query := r.withRelations().Where("user_id = ? AND is_deleted = ?", userID, false)
+	if err := paginate(query, "review_comments", page).Find(&comments).Error; err != nil {
+		return nil, nil, err
+	}
+	comments, info := buildPage(comments, page, reviewCommentKey)
+	return comments, info, nil
+}
+
+func (r *ReviewCommentRepository) GetReviewCommentsMentioningUser(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	var comments []*entity.ReviewComment
+	query := r.withRelations().
+		Where("is_deleted = ?", false).
+		Where("EXISTS (SELECT 1 FROM comment_mentions WHERE comment_mentions.comment_id = review_comments.id AND comment_mentions.user_id = ?)", userID)
+	if err := paginate(query, "review_comments", page).Find(&comments).Error; err != nil {
+		return nil, nil, err
+	}
+	comments, info := buildPage(comments, page, reviewCommentKey)
+	return comments, info, nil
+}
+
+func (r *ReviewCommentRepository) GetAllReviewComments(page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	var comments []*entity.ReviewComment
+	query := r.withRelations().Where("is_deleted = ?", false)
+	if err := paginate(query, "review_comments", page).Find(&comments).Error; err != nil {
+		return nil, nil, err
+	}
+	comments, info := buildPage(comments, page, reviewCommentKey)
+	return comments, info, nil
+}
+
+// UpdateReviewComment 本文を更新し、メンションを comment.Mentions で置き換える
 func (r *ReviewCommentRepository) UpdateReviewComment(comment *entity.ReviewComment) error {
-	return r.db.Save(comment).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 返信数とスレッド構造は返信の作成・削除でのみ変わる
+		if err := tx.Omit(clause.Associations, "parent_id", "root_id", "depth", "reply_count", "is_deleted").Save(comment).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&entity.CommentMention{}).Error; err != nil {
+			return err
+		}
+		if len(comment.Mentions) == 0 {
+			return nil
+		}
+		for n := range comment.Mentions {
+			comment.Mentions[n].ID = 0
+			comment.Mentions[n].CommentID = comment.ID
+		}
+		return tx.Create(&comment.Mentions).Error
+	})
 }
 
+// DeleteReviewComment 返信が残っているコメントは本文を消して残し、返信がなければ論理削除する
+// 返信を消したことで、プレースホルダーになっていた親に返信がなくなった場合は親も削除する。
 func (r *ReviewCommentRepository) DeleteReviewComment(id uint) error {
-	return r.db.Delete(&entity.ReviewComment{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for {
+			var comment entity.ReviewComment
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "parent_id", "reply_count", "is_deleted").
+				First(&comment, id).Error; err != nil {
+				return err
+			}
+
+			if comment.ReplyCount > 0 {
+				// 本文と一緒にメンションも消す
+				if err := tx.Where("comment_id = ?", comment.ID).Delete(&entity.CommentMention{}).Error; err != nil {
+					return err
+				}
+				return tx.Model(&comment).UpdateColumns(map[string]interface{}{
+					"comment":    entity.DeletedReviewCommentText,
+					"is_deleted": true,
+				}).Error
+			}
+
+			if err := tx.Delete(&entity.ReviewComment{}, comment.ID).Error; err != nil {
+				return err
+			}
+			if comment.ParentID == nil {
+				return nil
+			}
+
+			var parent entity.ReviewComment
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "reply_count", "is_deleted").
+				First(&parent, *comment.ParentID).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&parent).
+				UpdateColumn("reply_count", gorm.Expr("GREATEST(reply_count - 1, 0)")).Error; err != nil {
+				return err
+			}
+			if !parent.IsDeleted || parent.ReplyCount > 1 {
+				return nil
+			}
+			// 最後の返信が消えたプレースホルダーは残す理由がない
+			id = parent.ID
+		}
+	})
 }
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"sidemenulab-backend/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+// reviewSortExpressions 並び順ごとの先頭キー（newest は created_at, id のみで並べる）
+var reviewSortExpressions = map[entity.ReviewSort]string{
+	entity.ReviewSortNewest:       "",
+	entity.ReviewSortHighestRated: "side_menu_reviews.rating",
+	entity.ReviewSortMostLiked:    "side_menu_reviews.like_count",
+	entity.ReviewSortMostCommented: "(SELECT COUNT(*) FROM review_comments " +
+		"WHERE review_comments.review_id = side_menu_reviews.id AND review_comments.deleted_at IS NULL AND review_comments.is_deleted = false)",
+	entity.ReviewSortTrending: "side_menu_reviews.trending_score",
+}
+
+// applyReviewCriteria 絞り込み条件を side_menu_reviews へのWHERE句に変換する
+func applyReviewCriteria(query *gorm.DB, criteria entity.ReviewCriteria) *gorm.DB {
+	if criteria.StoreID != 0 {
+		query = query.Where("side_menu_reviews.store_id = ?", criteria.StoreID)
+	}
+	if criteria.StoreName != "" {
+		query = query.Where("side_menu_reviews.store_name = ?", criteria.StoreName)
+	}
+	if criteria.SideMenuID != 0 {
+		query = query.Where("side_menu_reviews.side_menu_id = ?", criteria.SideMenuID)
+	}
+	if criteria.SideMenuName != "" {
+		query = query.Where("side_menu_reviews.side_menu_name = ?", criteria.SideMenuName)
+	}
+	if criteria.MinRating > 0 {
+		query = query.Where("side_menu_reviews.rating >= ?", criteria.MinRating)
+	}
+	if criteria.MaxRating > 0 {
+		query = query.Where("side_menu_reviews.rating <= ?", criteria.MaxRating)
+	}
+	if criteria.UserID != 0 {
+		query = query.Where("side_menu_reviews.user_id = ?", criteria.UserID)
+	}
+	if criteria.VerifiedOnly {
+		query = query.Where("side_menu_reviews.is_verified = ?", true)
+	}
+	if criteria.HasImages {
+		query = query.Where("EXISTS (SELECT 1 FROM side_menu_review_images WHERE side_menu_review_images.review_id = side_menu_reviews.id)")
+	}
+	if criteria.From != nil {
+		query = query.Where("side_menu_reviews.created_at >= ?", *criteria.From)
+	}
+	if criteria.To != nil {
+		query = query.Where("side_menu_reviews.created_at < ?", *criteria.To)
+	}
+	return query
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB データベースに接続せずにSQLを組み立てるだけの接続
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func reviewCriteriaSQL(t *testing.T, criteria entity.ReviewCriteria) string {
+	t.Helper()
+	db := dryRunDB(t)
+	return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var reviews []*entity.SideMenuReview
+		return applyReviewCriteria(tx.Model(&entity.SideMenuReview{}), criteria).Find(&reviews)
+	})
+}
+
+func TestApplyReviewCriteria(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		criteria entity.ReviewCriteria
+		want     []string
+	}{
+		{name: "店舗ID", criteria: entity.ReviewCriteria{StoreID: 3}, want: []string{"side_menu_reviews.store_id = 3"}},
+		{name: "店舗名", criteria: entity.ReviewCriteria{StoreName: "マクドナルド"}, want: []string{"side_menu_reviews.store_name = 'マクドナルド'"}},
+		{name: "サイドメニューID", criteria: entity.ReviewCriteria{SideMenuID: 7}, want: []string{"side_menu_reviews.side_menu_id = 7"}},
+		{name: "サイドメニュー名", criteria: entity.ReviewCriteria{SideMenuName: "ポテト"}, want: []string{"side_menu_reviews.side_menu_name = 'ポテト'"}},
+		{
+			name:     "評価の範囲",
+			criteria: entity.ReviewCriteria{MinRating: 2, MaxRating: 4},
+			want:     []string{"side_menu_reviews.rating >= 2", "side_menu_reviews.rating <= 4"},
+		},
+		{name: "投稿者", criteria: entity.ReviewCriteria{UserID: 5}, want: []string{"side_menu_reviews.user_id = 5"}},
+		{name: "確認済みのみ", criteria: entity.ReviewCriteria{VerifiedOnly: true}, want: []string{"side_menu_reviews.is_verified = true"}},
+		{name: "画像あり", criteria: entity.ReviewCriteria{HasImages: true}, want: []string{"EXISTS (SELECT 1 FROM side_menu_review_images WHERE side_menu_review_images.review_id = side_menu_reviews.id)"}},
+		{
+			name:     "期間",
+			criteria: entity.ReviewCriteria{From: &from, To: &to},
+			want:     []string{"side_menu_reviews.created_at >= '2025-01-01 00:00:00'", "side_menu_reviews.created_at < '2025-02-01 00:00:00'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := reviewCriteriaSQL(t, tt.criteria)
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL に %q が含まれていません: %s", want, sql)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyReviewCriteriaWithoutConditions(t *testing.T) {
+	sql := reviewCriteriaSQL(t, entity.ReviewCriteria{})
+	// 削除済みを除く条件のみになる
+	if want := `WHERE "side_menu_reviews"."deleted_at" IS NULL`; !strings.HasSuffix(sql, want) {
+		t.Errorf("SQL = %s, want suffix %s", sql, want)
+	}
+}
+
+func TestReviewSortExpressions(t *testing.T) {
+	for _, sort := range []entity.ReviewSort{
+		entity.ReviewSortNewest,
+		entity.ReviewSortHighestRated,
+		entity.ReviewSortMostLiked,
+		entity.ReviewSortMostCommented,
+		entity.ReviewSortTrending,
+	} {
+		if _, ok := reviewSortExpressions[sort]; !ok {
+			t.Errorf("並び順 %s の式が定義されていません", sort)
+		}
+	}
+}
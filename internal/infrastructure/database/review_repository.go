@@ -1,10 +1,14 @@
 package database
 
 import (
+	"fmt"
+	"time"
+
 	"sidemenulab-backend/internal/domain/entity"
 	"sidemenulab-backend/internal/domain/repository"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReviewRepository struct {
@@ -15,13 +19,19 @@ func NewReviewRepository(db *gorm.DB) repository.ReviewRepository {
 	return &ReviewRepository{db: db}
 }
 
+// CreateReview レビューを作成し、評価の集計に加える
 func (r *ReviewRepository) CreateReview(review *entity.SideMenuReview) error {
-	return r.db.Create(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return applyRatingDelta(tx, review, 1)
+	})
 }
 
 func (r *ReviewRepository) GetReviewByID(id uint) (*entity.SideMenuReview, error) {
 	var review entity.SideMenuReview
-	if err := r.db.Preload("User").Preload("Images", func(db *gorm.DB) *gorm.DB {
+	if err := r.db.Preload("User").Preload("Store").Preload("SideMenu").Preload("Images", func(db *gorm.DB) *gorm.DB {
 		return db.Order("image_order")
 	}).First(&review, id).Error; err != nil {
 		return nil, err
@@ -29,61 +39,312 @@ func (r *ReviewRepository) GetReviewByID(id uint) (*entity.SideMenuReview, error
 	return &review, nil
 }
 
-func (r *ReviewRepository) GetReviewsByStoreName(storeName string) ([]*entity.SideMenuReview, error) {
+func (r *ReviewRepository) GetReviewsByStoreName(storeName string, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
 	var reviews []*entity.SideMenuReview
-	if err := r.db.Preload("User").Preload("Images", func(db *gorm.DB) *gorm.DB {
+	query := r.db.Preload("User").Preload("Store").Preload("SideMenu").Preload("Images", func(db *gorm.DB) *gorm.DB {
 		return db.Order("image_order")
-	}).Where("store_name = ?", storeName).Order("created_at DESC").Find(&reviews).Error; err != nil {
-		return nil, err
+	}).Where("store_name = ?", storeName)
+	if err := paginate(query, "side_menu_reviews", page).Find(&reviews).Error; err != nil {
+		return nil, nil, err
 	}
-	return reviews, nil
+	reviews, info := buildPage(reviews, page, reviewKey)
+	return reviews, info, nil
 }
 
-func (r *ReviewRepository) GetReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error) {
+func (r *ReviewRepository) GetReviewsByStoreID(storeID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
 	var reviews []*entity.SideMenuReview
-	if err := r.db.Preload("User").Preload("Images", func(db *gorm.DB) *gorm.DB {
+	query := r.db.Preload("User").Preload("Store").Preload("SideMenu").Preload("Images", func(db *gorm.DB) *gorm.DB {
 		return db.Order("image_order")
-	}).Where("user_id = ?", userID).Order("created_at DESC").Find(&reviews).Error; err != nil {
-		return nil, err
+	}).Where("store_id = ?", storeID)
+	if err := paginate(query, "side_menu_reviews", page).Find(&reviews).Error; err != nil {
+		return nil, nil, err
 	}
-	return reviews, nil
+	reviews, info := buildPage(reviews, page, reviewKey)
+	return reviews, info, nil
 }
 
-func (r *ReviewRepository) GetAllReviews() ([]*entity.SideMenuReview, error) {
+func (r *ReviewRepository) GetReviewsBySideMenuID(sideMenuID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
 	var reviews []*entity.SideMenuReview
-	if err := r.db.Preload("User").Preload("Images", func(db *gorm.DB) *gorm.DB {
+	query := r.db.Preload("User").Preload("Store").Preload("SideMenu").Preload("Images", func(db *gorm.DB) *gorm.DB {
 		return db.Order("image_order")
-	}).Order("created_at DESC").Find(&reviews).Error; err != nil {
-		return nil, err
+	}).Where("side_menu_id = ?", sideMenuID)
+	if err := paginate(query, "side_menu_reviews", page).Find(&reviews).Error; err != nil {
+		return nil, nil, err
 	}
-	return reviews, nil
+	reviews, info := buildPage(reviews, page, reviewKey)
+	return reviews, info, nil
 }
 
-func (r *ReviewRepository) GetLikedReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error) {
+func (r *ReviewRepository) GetReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
 	var reviews []*entity.SideMenuReview
-	if err := r.db.Preload("User").Preload("Images", func(db *gorm.DB) *gorm.DB {
+	query := r.db.Preload("User").Preload("Store").Preload("SideMenu").Preload("Images", func(db *gorm.DB) *gorm.DB {
+		return db.Order("image_order")
+	}).Where("user_id = ?", userID)
+	if err := paginate(query, "side_menu_reviews", page).Find(&reviews).Error; err != nil {
+		return nil, nil, err
+	}
+	reviews, info := buildPage(reviews, page, reviewKey)
+	return reviews, info, nil
+}
+
+// reviewSortRow 並べ替えの対象となるレビューのキー
+type reviewSortRow struct {
+	ID        uint
+	CreatedAt time.Time
+	SortValue int64
+}
+
+// SearchReviews 条件に合うレビューを並び順に従ってページングする
+// 先にキーだけを並べ替えて取得し、表示分のレビューを関連データと一緒に読み込む。
+func (r *ReviewRepository) SearchReviews(criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	// 並び順が異なるカーソルは位置の意味が変わるため受け付けない
+	if page.Cursor != nil && page.Cursor.Sort != string(criteria.Sort) {
+		return nil, nil, entity.ErrInvalidCursor
+	}
+
+	sortExpr := reviewSortExpressions[criteria.Sort]
+	// トレンド順は定期ジョブがスコアを書き換えても位置がずれないよう、カーソルに記録した世代のスコアで並べる
+	var generation uint64
+	if criteria.Sort == entity.ReviewSortTrending {
+		oldest, latest, err := trendingGenerations(r.db)
+		if err != nil {
+			return nil, nil, err
+		}
+		generation = latest
+		if page.Cursor != nil {
+			if page.Cursor.Generation < oldest || page.Cursor.Generation > latest {
+				return nil, nil, entity.ErrInvalidCursor
+			}
+			generation = page.Cursor.Generation
+		}
+		sortExpr = trendingScoreAt(generation)
+	}
+	selectExpr := "side_menu_reviews.id, side_menu_reviews.created_at"
+	if sortExpr != "" {
+		selectExpr += ", " + sortExpr + " AS sort_value"
+	}
+
+	var rows []*reviewSortRow
+	query := applyReviewCriteria(r.db.Model(&entity.SideMenuReview{}).Select(selectExpr), criteria)
+	if err := paginateBy(query, sortExpr, "side_menu_reviews", page).Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+	rows, info := buildPage(rows, page, func(row *reviewSortRow) entity.Cursor {
+		return entity.Cursor{CreatedAt: row.CreatedAt, ID: row.ID, Sort: string(criteria.Sort), Value: row.SortValue, Generation: generation}
+	})
+
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	reviews, err := findReviewsByIDs(r.db, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reviews, info, nil
+}
+
+// findReviewsByIDs 指定したIDのレビューを関連データと一緒に ids の順で取得する
+func findReviewsByIDs(db *gorm.DB, ids []uint) ([]*entity.SideMenuReview, error) {
+	reviews := make([]*entity.SideMenuReview, 0, len(ids))
+	if len(ids) == 0 {
+		return reviews, nil
+	}
+
+	var found []*entity.SideMenuReview
+	if err := db.Preload("User").Preload("Store").Preload("SideMenu").Preload("Images", func(db *gorm.DB) *gorm.DB {
 		return db.Order("image_order")
-	}).Joins("JOIN side_menu_review_likes ON side_menu_reviews.id = side_menu_review_likes.review_id").
-		Where("side_menu_review_likes.user_id = ?", userID).
-		Order("side_menu_review_likes.created_at DESC").
-		Find(&reviews).Error; err != nil {
+	}).Where("id IN ?", ids).Find(&found).Error; err != nil {
 		return nil, err
 	}
+
+	byID := make(map[uint]*entity.SideMenuReview, len(found))
+	for _, review := range found {
+		byID[review.ID] = review
+	}
+	for _, id := range ids {
+		if review, ok := byID[id]; ok {
+			reviews = append(reviews, review)
+		}
+	}
 	return reviews, nil
 }
 
+func (r *ReviewRepository) GetLikedReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	// いいねの日時順に並べるため、いいねを起点にページングしてレビューを取り出す
+	var likes []*entity.SideMenuReviewLike
+	query := r.db.Preload("Review.User").Preload("Review.Store").Preload("Review.SideMenu").Preload("Review.Images", func(db *gorm.DB) *gorm.DB {
+		return db.Order("image_order")
+	}).Joins("JOIN side_menu_reviews ON side_menu_reviews.id = side_menu_review_likes.review_id AND side_menu_reviews.deleted_at IS NULL").
+		Where("side_menu_review_likes.user_id = ?", userID)
+	if err := paginate(query, "side_menu_review_likes", page).Find(&likes).Error; err != nil {
+		return nil, nil, err
+	}
+	likes, info := buildPage(likes, page, reviewLikeKey)
+
+	reviews := make([]*entity.SideMenuReview, 0, len(likes))
+	for _, like := range likes {
+		review := like.Review
+		reviews = append(reviews, &review)
+	}
+	return reviews, info, nil
+}
+
+// trendingRefreshLockKey トレンドスコアの更新を複数のインスタンスで同時に行わないためのアドバイザリロックのキー
+const trendingRefreshLockKey = 20251022
+
+// RefreshTrendingScores いいね数・コメント数からトレンドスコアを計算し直し、新しい世代として記録する
+// 値が変わるレビューだけを更新し、更新前の値を世代と一緒に残す（古い世代のカーソルで並び順を再現するため）。
+// 対象期間を過ぎたレビューと削除されたレビューのスコアは 0 に戻す。updated_at は変更しない。
+func (r *ReviewRepository) RefreshTrendingScores(policy entity.TrendingPolicy) (int64, error) {
+	now := time.Now()
+	since := now.Add(-policy.Window())
+	var updated int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", trendingRefreshLockKey).Error; err != nil {
+			return err
+		}
+
+		generation := &entity.TrendingGeneration{}
+		if err := tx.Create(generation).Error; err != nil {
+			return err
+		}
+		result := tx.Exec("WITH scores AS ("+
+			"SELECT id, trending_score AS previous, CASE WHEN deleted_at IS NULL AND created_at >= ? THEN "+trendingScoreExpr+" ELSE 0 END AS score "+
+			"FROM side_menu_reviews WHERE (deleted_at IS NULL AND created_at >= ?) OR trending_score <> 0"+
+			"), changed AS ("+
+			"UPDATE side_menu_reviews SET trending_score = scores.score FROM scores "+
+			"WHERE side_menu_reviews.id = scores.id AND scores.score <> scores.previous "+
+			"RETURNING scores.id, scores.previous"+
+			") INSERT INTO trending_score_changes (generation, review_id, previous_score) SELECT ?, id, previous FROM changed",
+			since, entity.TrendingScoreScale, policy.LikeWeight, policy.CommentWeight, policy.HalfLife.Seconds(), since, generation.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		updated = result.RowsAffected
+
+		// 変わったスコアがなければ世代を進めない
+		if updated == 0 {
+			if err := tx.Delete(generation).Error; err != nil {
+				return err
+			}
+		}
+		return pruneTrendingGenerations(tx, now.Add(-entity.TrendingSnapshotRetention))
+	})
+	return updated, err
+}
+
+// trendingScoreExpr TrendingPolicy.Score と同じ計算を行うSQL（倍率・いいねの重み・コメントの重み・半減期の秒数を順に渡す）
+const trendingScoreExpr = "ROUND(?::float8 * (" +
+	"LN(1 + ?::float8 * side_menu_reviews.like_count " +
+	"+ ?::float8 * (SELECT COUNT(*) FROM review_comments WHERE review_comments.review_id = side_menu_reviews.id AND review_comments.deleted_at IS NULL AND review_comments.is_deleted = false)" +
+	") / LN(2) + EXTRACT(EPOCH FROM side_menu_reviews.created_at)::float8 / ?::float8))::bigint"
+
+// pruneTrendingGenerations cutoff より前に次の世代に置き換わった世代と、その再現にしか使わない更新前の値を削除する
+// cutoff の時点で最新だった世代は、それ以降に発行されたカーソルが参照するため残す。
+func pruneTrendingGenerations(tx *gorm.DB, cutoff time.Time) error {
+	var keep uint64
+	if err := tx.Model(&entity.TrendingGeneration{}).Where("created_at < ?", cutoff).
+		Select("COALESCE(MAX(id), 0)").Scan(&keep).Error; err != nil {
+		return err
+	}
+	if keep == 0 {
+		return nil
+	}
+	if err := tx.Where("id < ?", keep).Delete(&entity.TrendingGeneration{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("generation <= ?", keep).Delete(&entity.TrendingScoreChange{}).Error
+}
+
+// trendingGenerations トレンド順のカーソルが参照できる世代の範囲（記録がなければ 0, 0）
+func trendingGenerations(db *gorm.DB) (oldest uint64, latest uint64, err error) {
+	var bounds struct {
+		Oldest uint64
+		Latest uint64
+	}
+	err = db.Model(&entity.TrendingGeneration{}).
+		Select("COALESCE(MIN(id), 0) AS oldest, COALESCE(MAX(id), 0) AS latest").
+		Scan(&bounds).Error
+	return bounds.Oldest, bounds.Latest, err
+}
+
+// trendingScoreAt 世代 generation の時点のトレンドスコア
+// その世代より後に書き換えられたレビューは、最初に書き換えられる前の値を使う。
+func trendingScoreAt(generation uint64) string {
+	return fmt.Sprintf("COALESCE((SELECT trending_score_changes.previous_score FROM trending_score_changes "+
+		"WHERE trending_score_changes.review_id = side_menu_reviews.id AND trending_score_changes.generation > %d "+
+		"ORDER BY trending_score_changes.generation LIMIT 1), side_menu_reviews.trending_score)", generation)
+}
+
+// UpdateReview レビューを更新し、評価・店舗・サイドメニューが変わった場合は集計を付け替える
 func (r *ReviewRepository) UpdateReview(review *entity.SideMenuReview) error {
-	return r.db.Save(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := lockReviewRating(tx, review.ID)
+		if err != nil {
+			return err
+		}
+		// いいね数とトレンドスコアは別の経路で更新されるため、読み込んだ時点の値で上書きしない
+		if err := tx.Omit("like_count", "trending_score").Save(review).Error; err != nil {
+			return err
+		}
+		if previous.Rating == review.Rating && equalID(previous.StoreID, review.StoreID) && equalID(previous.SideMenuID, review.SideMenuID) {
+			return nil
+		}
+		if err := applyRatingDelta(tx, previous, -1); err != nil {
+			return err
+		}
+		return applyRatingDelta(tx, review, 1)
+	})
 }
 
+// DeleteReview レビューを削除し、評価の集計から取り除く
 func (r *ReviewRepository) DeleteReview(id uint) error {
-	return r.db.Delete(&entity.SideMenuReview{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := lockReviewRating(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&entity.SideMenuReview{}, id).Error; err != nil {
+			return err
+		}
+		if err := deleteReviewImages(tx, "review_id = ?", id); err != nil {
+			return err
+		}
+		return applyRatingDelta(tx, previous, -1)
+	})
+}
+
+// lockReviewRating 集計に関わる列を、同じレビューへの同時更新を待たせた状態で読み込む
+func lockReviewRating(tx *gorm.DB, id uint) (*entity.SideMenuReview, error) {
+	var review entity.SideMenuReview
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "store_id", "side_menu_id", "rating").
+		First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func equalID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 func (r *ReviewRepository) CreateReviewImage(image *entity.SideMenuReviewImage) error {
 	return r.db.Create(image).Error
 }
 
+func (r *ReviewRepository) GetReviewImageByID(imageID uint) (*entity.SideMenuReviewImage, error) {
+	var image entity.SideMenuReviewImage
+	if err := r.db.Preload("Review").First(&image, imageID).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
 func (r *ReviewRepository) GetReviewImagesByReviewID(reviewID uint) ([]*entity.SideMenuReviewImage, error) {
 	var images []*entity.SideMenuReviewImage
 	if err := r.db.Where("review_id = ?", reviewID).Order("image_order").Find(&images).Error; err != nil {
@@ -92,22 +353,138 @@ func (r *ReviewRepository) GetReviewImagesByReviewID(reviewID uint) ([]*entity.S
 	return images, nil
 }
 
+// DeleteReviewImage 画像の行を削除し、保存先からの削除ジョブを登録する
 func (r *ReviewRepository) DeleteReviewImage(imageID uint) error {
-	return r.db.Delete(&entity.SideMenuReviewImage{}, imageID).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteReviewImages(tx, "id = ?", imageID)
+	})
 }
 
-func (r *ReviewRepository) CreateReviewLike(like *entity.SideMenuReviewLike) error {
-	return r.db.Create(like).Error
+func (r *ReviewRepository) GetReferencedImageKeys(provider string, keys []string) (map[string]bool, error) {
+	return pluckExistingKeys(r.db.Model(&entity.SideMenuReviewImage{}).Where("storage_provider = ?", provider), keys)
 }
 
-func (r *ReviewRepository) DeleteReviewLike(reviewID uint, userID uint) error {
-	return r.db.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&entity.SideMenuReviewLike{}).Error
+func (r *ReviewRepository) GetUnkeyedReviewImageURLs() ([]string, error) {
+	var urls []string
+	if err := r.db.Model(&entity.SideMenuReviewImage{}).
+		Where("storage_key IS NULL OR storage_key = ''").
+		Pluck("image_url", &urls).Error; err != nil {
+		return nil, err
+	}
+	return urls, nil
 }
 
-func (r *ReviewRepository) GetReviewLikesByReviewID(reviewID uint) ([]*entity.SideMenuReviewLike, error) {
-	var likes []*entity.SideMenuReviewLike
-	if err := r.db.Preload("User").Where("review_id = ?", reviewID).Find(&likes).Error; err != nil {
+// storageKeyChunkSize 1回のクエリで IN 句に渡すキーの最大数
+const storageKeyChunkSize = 1000
+
+// pluckExistingKeys keys のうち、query の storage_key 列に存在するものを返す
+func pluckExistingKeys(query *gorm.DB, keys []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	for start := 0; start < len(keys); start += storageKeyChunkSize {
+		end := min(start+storageKeyChunkSize, len(keys))
+
+		var found []string
+		if err := query.Session(&gorm.Session{}).
+			Where("storage_key IN ?", keys[start:end]).
+			Distinct().
+			Pluck("storage_key", &found).Error; err != nil {
+			return nil, err
+		}
+		for _, key := range found {
+			existing[key] = true
+		}
+	}
+	return existing, nil
+}
+
+// deleteReviewImages 条件に合う画像の行を削除し、保存先からの削除ジョブを登録する
+func deleteReviewImages(tx *gorm.DB, query interface{}, args ...interface{}) error {
+	var images []*entity.SideMenuReviewImage
+	if err := tx.Where(query, args...).Find(&images).Error; err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(images))
+	for i, image := range images {
+		ids[i] = image.ID
+	}
+	if err := tx.Delete(&entity.SideMenuReviewImage{}, ids).Error; err != nil {
+		return err
+	}
+
+	jobs := entity.NewImageDeletionJobs(images, time.Now())
+	if len(jobs) == 0 {
+		return nil
+	}
+	return tx.Create(&jobs).Error
+}
+
+// CreateReviewLike いいねを登録し、レビューのいいね数を増やす
+// 既にいいねしている場合は何もせず、既存のいいねを like に読み込んで false を返す。
+func (r *ReviewRepository) CreateReviewLike(like *entity.SideMenuReviewLike) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).Create(like)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return tx.Where("review_id = ? AND user_id = ?", like.ReviewID, like.UserID).First(like).Error
+		}
+
+		created = true
+		return tx.Model(&entity.SideMenuReview{}).Where("id = ?", like.ReviewID).
+			UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error
+	})
+	return created, err
+}
+
+// DeleteReviewLike いいねを取り消し、レビューのいいね数を減らす（いいねしていない場合は何もせず false を返す）
+func (r *ReviewRepository) DeleteReviewLike(reviewID uint, userID uint) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&entity.SideMenuReviewLike{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = true
+		return tx.Unscoped().Model(&entity.SideMenuReview{}).Where("id = ?", reviewID).
+			UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - 1, 0)")).Error
+	})
+	return deleted, err
+}
+
+// GetLikedReviewIDs reviewIDs のうち、ユーザーがいいねしているレビューのIDを返す
+func (r *ReviewRepository) GetLikedReviewIDs(userID uint, reviewIDs []uint) (map[uint]bool, error) {
+	liked := make(map[uint]bool)
+	if len(reviewIDs) == 0 {
+		return liked, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&entity.SideMenuReviewLike{}).
+		Where("user_id = ? AND review_id IN ?", userID, reviewIDs).
+		Pluck("review_id", &ids).Error; err != nil {
 		return nil, err
 	}
-	return likes, nil
+	for _, id := range ids {
+		liked[id] = true
+	}
+	return liked, nil
+}
+
+func (r *ReviewRepository) GetReviewLikesByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.SideMenuReviewLike, *entity.PageInfo, error) {
+	var likes []*entity.SideMenuReviewLike
+	query := r.db.Preload("User").Where("review_id = ?", reviewID)
+	if err := paginate(query, "side_menu_review_likes", page).Find(&likes).Error; err != nil {
+		return nil, nil, err
+	}
+	likes, info := buildPage(likes, page, reviewLikeKey)
+	return likes, info, nil
 }
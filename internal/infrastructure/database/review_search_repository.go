@@ -0,0 +1,127 @@
+package database
+
+import (
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// reviewSearchSort 検索結果のカーソルに記録する並び順の名前
+	reviewSearchSort = "relevance"
+	// reviewSearchScoreScale 関連度を整数でカーソルに保持するための倍率
+	reviewSearchScoreScale = 1000
+	// reviewSearchKeyWeight 店舗名・メニュー名・タイトルで一致した語1つあたりの加点
+	reviewSearchKeyWeight = 2
+)
+
+type reviewSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewSearchRepository pg_trgm と正規化済みの検索用カラムを使った全文検索リポジトリを作成する
+func NewReviewSearchRepository(db *gorm.DB) repository.ReviewSearchRepository {
+	return &reviewSearchRepository{db: db}
+}
+
+// SearchReviews すべての語を含むレビューを関連度の高い順にページングする
+// 関連度は「名前系のフィールドで一致した語の数 × 重み」と、本文全体との word_similarity の和。
+func (r *reviewSearchRepository) SearchReviews(query entity.SearchQuery, criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.ReviewSearchHit, *entity.PageInfo, error) {
+	if page.Cursor != nil && page.Cursor.Sort != reviewSearchSort {
+		return nil, nil, entity.ErrInvalidCursor
+	}
+
+	var conditions, scores []string
+	var conditionVars, scoreVars []interface{}
+	for _, term := range query.Terms {
+		textMatches := make([]string, 0, len(term))
+		keyMatches := make([]string, 0, len(term))
+		for _, candidate := range term {
+			pattern := likePattern(candidate)
+			textMatches = append(textMatches, "side_menu_reviews.search_text LIKE ?")
+			keyMatches = append(keyMatches, "side_menu_reviews.search_key LIKE ?")
+			conditionVars = append(conditionVars, pattern)
+			scoreVars = append(scoreVars, pattern)
+		}
+		conditions = append(conditions, "("+strings.Join(textMatches, " OR ")+")")
+		scores = append(scores, "CASE WHEN "+strings.Join(keyMatches, " OR ")+" THEN ? ELSE 0 END")
+		scoreVars = append(scoreVars, reviewSearchKeyWeight)
+	}
+	scoreExpr := strings.Join(scores, " + ") + " + word_similarity(?, side_menu_reviews.search_text)"
+	scoreVars = append(scoreVars, query.Text, reviewSearchScoreScale)
+
+	ranked := applyReviewCriteria(r.db.Model(&entity.SideMenuReview{}), criteria).
+		Select("side_menu_reviews.id, side_menu_reviews.created_at, CAST(ROUND(("+scoreExpr+") * ?) AS bigint) AS sort_value", scoreVars...).
+		Where(strings.Join(conditions, " AND "), conditionVars...)
+
+	var rows []*reviewSortRow
+	rankedQuery := r.db.Table("(?) AS ranked", ranked).Select("ranked.id, ranked.created_at, ranked.sort_value")
+	if err := paginateBy(rankedQuery, "ranked.sort_value", "ranked", page).Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+	rows, info := buildPage(rows, page, func(row *reviewSortRow) entity.Cursor {
+		return entity.Cursor{CreatedAt: row.CreatedAt, ID: row.ID, Sort: reviewSearchSort, Value: row.SortValue}
+	})
+
+	ids := make([]uint, 0, len(rows))
+	scoreByID := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		scoreByID[row.ID] = row.SortValue
+	}
+	reviews, err := findReviewsByIDs(r.db, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hits := make([]*entity.ReviewSearchHit, 0, len(reviews))
+	for _, review := range reviews {
+		hits = append(hits, &entity.ReviewSearchHit{
+			Review: review,
+			Score:  float64(scoreByID[review.ID]) / reviewSearchScoreScale,
+		})
+	}
+	return hits, info, nil
+}
+
+// likePattern 部分一致用の LIKE パターンを作る（ワイルドカード文字はエスケープする）
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
+
+// renameReviews 店舗・サイドメニューの名前の変更を、紐付いたレビューの名前と検索用テキストに反映する
+// idColumn は store_id または side_menu_id、nameColumn はそれに対応する store_name または side_menu_name。
+// 検索用テキストの正規化はアプリケーション側で行うため、名前の異なるレビューを読み出して1件ずつ更新する。
+func renameReviews(tx *gorm.DB, idColumn string, id uint, nameColumn string, name string) error {
+	var reviews []*entity.SideMenuReview
+	return tx.Unscoped().Where(idColumn+" = ? AND "+nameColumn+" <> ?", id, name).FindInBatches(&reviews, 500, func(_ *gorm.DB, _ int) error {
+		for _, review := range reviews {
+			switch nameColumn {
+			case "store_name":
+				review.StoreName = name
+			case "side_menu_name":
+				review.SideMenuName = name
+			}
+			if err := updateReviewSearchText(tx, review, map[string]interface{}{nameColumn: name}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// updateReviewSearchText レビューの検索用テキストを現在の内容から計算し直して、columns と合わせて更新する
+// 更新日時は変えない（レビューの内容の更新ではないため）。
+func updateReviewSearchText(tx *gorm.DB, review *entity.SideMenuReview, columns map[string]interface{}) error {
+	key, text := review.BuildSearchText()
+	if columns == nil {
+		columns = make(map[string]interface{})
+	}
+	columns["search_key"] = key
+	columns["search_text"] = text
+	return tx.Unscoped().Model(review).UpdateColumns(columns).Error
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"log"
+	"time"
 
 	"sidemenulab-backend/internal/domain/entity"
 
@@ -53,26 +54,42 @@ func seedUsers(db *gorm.DB) error {
 		return err
 	}
 
+	// 初期ユーザーはメールアドレス確認済みとして登録
+	// パスワードが公開されているため管理者ロールは付与しない（管理者は grant-admin サブコマンドで設定する）
+	verifiedAt := time.Now()
+
 	users := []entity.User{
 		{
-			Email:    "admin@sidemenulab.com",
-			Password: string(hashedPassword),
-			Name:     "管理者",
+			Email:           "admin@sidemenulab.com",
+			Handle:          "admin",
+			Password:        string(hashedPassword),
+			Name:            "管理者",
+			Role:            entity.RoleUser,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "user1@example.com",
-			Password: string(hashedPassword),
-			Name:     "田中太郎",
+			Email:           "user1@example.com",
+			Handle:          "tanaka_taro",
+			Password:        string(hashedPassword),
+			Name:            "田中太郎",
+			Role:            entity.RoleUser,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "user2@example.com",
-			Password: string(hashedPassword),
-			Name:     "佐藤花子",
+			Email:           "user2@example.com",
+			Handle:          "sato_hanako",
+			Password:        string(hashedPassword),
+			Name:            "佐藤花子",
+			Role:            entity.RoleUser,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "user3@example.com",
-			Password: string(hashedPassword),
-			Name:     "鈴木一郎",
+			Email:           "user3@example.com",
+			Handle:          "suzuki_ichiro",
+			Password:        string(hashedPassword),
+			Name:            "鈴木一郎",
+			Role:            entity.RoleUser,
+			EmailVerifiedAt: &verifiedAt,
 		},
 	}
 
@@ -96,11 +113,22 @@ func seedReviews(db *gorm.DB) error {
 		return nil
 	}
 
+	store, err := findOrCreateStoreByName(db, "サンプル店舗")
+	if err != nil {
+		return err
+	}
+	sideMenu, err := findOrCreateSideMenuByName(db, store.ID, "サンプルサイドメニュー")
+	if err != nil {
+		return err
+	}
+
 	reviews := []entity.SideMenuReview{
 		{
 			UserID:       2,
-			StoreName:    "サンプル店舗",
-			SideMenuName: "サンプルサイドメニュー",
+			StoreID:      &store.ID,
+			StoreName:    store.Name,
+			SideMenuID:   &sideMenu.ID,
+			SideMenuName: sideMenu.Name,
 			Rating:       5,
 			Title:        "とても美味しかった！",
 			Comment:      "新鮮な野菜で、ドレッシングも絶品でした。また食べたいです。",
@@ -108,8 +136,10 @@ func seedReviews(db *gorm.DB) error {
 		},
 		{
 			UserID:       3,
-			StoreName:    "サンプル店舗",
-			SideMenuName: "サンプルサイドメニュー",
+			StoreID:      &store.ID,
+			StoreName:    store.Name,
+			SideMenuID:   &sideMenu.ID,
+			SideMenuName: sideMenu.Name,
 			Rating:       4,
 			Title:        "良いサラダ",
 			Comment:      "野菜が新鮮で美味しかったです。",
@@ -117,8 +147,10 @@ func seedReviews(db *gorm.DB) error {
 		},
 		{
 			UserID:       2,
-			StoreName:    "サンプル店舗",
-			SideMenuName: "サンプルサイドメニュー",
+			StoreID:      &store.ID,
+			StoreName:    store.Name,
+			SideMenuID:   &sideMenu.ID,
+			SideMenuName: sideMenu.Name,
 			Rating:       5,
 			Title:        "最高のポテトフライ",
 			Comment:      "カリッとしていて、塩加減も絶妙でした。",
@@ -126,8 +158,10 @@ func seedReviews(db *gorm.DB) error {
 		},
 		{
 			UserID:       4,
-			StoreName:    "サンプル店舗",
-			SideMenuName: "サンプルサイドメニュー",
+			StoreID:      &store.ID,
+			StoreName:    store.Name,
+			SideMenuID:   &sideMenu.ID,
+			SideMenuName: sideMenu.Name,
 			Rating:       3,
 			Title:        "普通のポテトフライ",
 			Comment:      "特に特徴はありませんが、美味しかったです。",
@@ -135,8 +169,10 @@ func seedReviews(db *gorm.DB) error {
 		},
 		{
 			UserID:       3,
-			StoreName:    "サンプル店舗",
-			SideMenuName: "サンプルサイドメニュー",
+			StoreID:      &store.ID,
+			StoreName:    store.Name,
+			SideMenuID:   &sideMenu.ID,
+			SideMenuName: sideMenu.Name,
 			Rating:       4,
 			Title:        "ジューシーなチキン",
 			Comment:      "チキンが柔らかくて美味しかったです。",
@@ -144,8 +180,10 @@ func seedReviews(db *gorm.DB) error {
 		},
 		{
 			UserID:       4,
-			StoreName:    "サンプル店舗",
-			SideMenuName: "サンプルサイドメニュー",
+			StoreID:      &store.ID,
+			StoreName:    store.Name,
+			SideMenuID:   &sideMenu.ID,
+			SideMenuName: sideMenu.Name,
 			Rating:       5,
 			Title:        "エビがプリプリ",
 			Comment:      "エビが新鮮で、衣もサクサクでした。",
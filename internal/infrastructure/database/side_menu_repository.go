@@ -0,0 +1,132 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sideMenuRepository struct {
+	db *gorm.DB
+}
+
+func NewSideMenuRepository(db *gorm.DB) repository.SideMenuRepository {
+	return &sideMenuRepository{db: db}
+}
+
+func (r *sideMenuRepository) Create(menu *entity.SideMenu) error {
+	return r.db.Omit(clause.Associations).Create(menu).Error
+}
+
+func (r *sideMenuRepository) GetByID(id uint) (*entity.SideMenu, error) {
+	var menu entity.SideMenu
+	if err := r.db.Preload("Store").First(&menu, id).Error; err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+func (r *sideMenuRepository) FindByName(storeID uint, name string) (*entity.SideMenu, error) {
+	return findSideMenuByName(r.db, storeID, name)
+}
+
+func (r *sideMenuRepository) FindOrCreateByName(storeID uint, name string) (*entity.SideMenu, error) {
+	return findOrCreateSideMenuByName(r.db, storeID, name)
+}
+
+func (r *sideMenuRepository) List(filter entity.SideMenuFilter, page entity.PageRequest) ([]*entity.SideMenu, *entity.PageInfo, error) {
+	var menus []*entity.SideMenu
+	query := r.db.Preload("Store")
+	if filter.StoreID != 0 {
+		query = query.Where("side_menus.store_id = ?", filter.StoreID)
+	}
+	if filter.Category != "" {
+		query = query.Where("side_menus.category = ?", filter.Category)
+	}
+	if normalized := entity.NormalizeStoreName(filter.Keyword); normalized != "" {
+		query = query.Where("side_menus.normalized_name LIKE ?", likePattern(normalized))
+	}
+	if filter.AvailableOnly {
+		query = query.Where("side_menus.is_available = ?", true)
+	}
+	if err := paginate(query, "side_menus", page).Find(&menus).Error; err != nil {
+		return nil, nil, err
+	}
+	menus, info := buildPage(menus, page, sideMenuKey)
+	return menus, info, nil
+}
+
+func (r *sideMenuRepository) Update(menu *entity.SideMenu) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(menu).Error; err != nil {
+			return err
+		}
+		return renameReviews(tx, "side_menu_id", menu.ID, "side_menu_name", menu.Name)
+	})
+}
+
+func (r *sideMenuRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.SideMenu{}, id).Error
+}
+
+func (r *sideMenuRepository) CountReviews(menuID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.SideMenuReview{}).Where("side_menu_id = ?", menuID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// findSideMenuByName 店舗内でメニュー名が正規化して一致するサイドメニューを探す（見つからない場合は nil, nil）
+func findSideMenuByName(db *gorm.DB, storeID uint, name string) (*entity.SideMenu, error) {
+	normalized := entity.NormalizeStoreName(name)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	var menu entity.SideMenu
+	err := db.Preload("Store").Where("store_id = ? AND normalized_name = ?", storeID, normalized).First(&menu).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+// findOrCreateSideMenuByName 一致するサイドメニューがなければメニュー名だけのサイドメニューを作成する
+// 同時に同じ名前で作成された場合は、先に作成されたサイドメニューを返す。
+func findOrCreateSideMenuByName(db *gorm.DB, storeID uint, name string) (*entity.SideMenu, error) {
+	if entity.NormalizeStoreName(name) == "" {
+		return nil, errors.New("サイドメニュー名が正しくありません")
+	}
+
+	menu, err := findSideMenuByName(db, storeID, name)
+	if err != nil || menu != nil {
+		return menu, err
+	}
+
+	menu = &entity.SideMenu{StoreID: storeID, Name: strings.TrimSpace(name), IsAvailable: true}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(menu).Error; err != nil {
+		return nil, err
+	}
+	if menu.ID != 0 {
+		return menu, nil
+	}
+
+	menu, err = findSideMenuByName(db, storeID, name)
+	if err == nil && menu == nil {
+		err = gorm.ErrRecordNotFound
+	}
+	return menu, err
+}
+
+func sideMenuKey(m *entity.SideMenu) entity.Cursor {
+	return entity.Cursor{CreatedAt: m.CreatedAt, ID: m.ID}
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type storeRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreRepository(db *gorm.DB) repository.StoreRepository {
+	return &storeRepository{db: db}
+}
+
+func (r *storeRepository) Create(store *entity.Store) error {
+	return r.db.Create(store).Error
+}
+
+func (r *storeRepository) GetByID(id uint) (*entity.Store, error) {
+	var store entity.Store
+	if err := r.db.Preload("Aliases").First(&store, id).Error; err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+func (r *storeRepository) FindByName(name string) (*entity.Store, error) {
+	return findStoreByName(r.db, name)
+}
+
+func (r *storeRepository) FindOrCreateByName(name string) (*entity.Store, error) {
+	return findOrCreateStoreByName(r.db, name)
+}
+
+func (r *storeRepository) List(keyword string, page entity.PageRequest) ([]*entity.Store, *entity.PageInfo, error) {
+	var stores []*entity.Store
+	query := r.db.Preload("Aliases")
+	if normalized := entity.NormalizeStoreName(keyword); normalized != "" {
+		pattern := likePattern(normalized)
+		query = query.Where("stores.normalized_name LIKE ? OR EXISTS (SELECT 1 FROM store_aliases WHERE store_aliases.store_id = stores.id AND store_aliases.normalized_name LIKE ?)", pattern, pattern)
+	}
+	if err := paginate(query, "stores", page).Find(&stores).Error; err != nil {
+		return nil, nil, err
+	}
+	stores, info := buildPage(stores, page, storeKey)
+	return stores, info, nil
+}
+
+func (r *storeRepository) Update(store *entity.Store) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(store).Error; err != nil {
+			return err
+		}
+		if err := renameReviews(tx, "store_id", store.ID, "store_name", store.Name); err != nil {
+			return err
+		}
+		if err := tx.Where("store_id = ?", store.ID).Delete(&entity.StoreAlias{}).Error; err != nil {
+			return err
+		}
+		for i := range store.Aliases {
+			store.Aliases[i].ID = 0
+			store.Aliases[i].StoreID = store.ID
+		}
+		if len(store.Aliases) == 0 {
+			return nil
+		}
+		return tx.Create(&store.Aliases).Error
+	})
+}
+
+func (r *storeRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Store{}, id).Error
+}
+
+func (r *storeRepository) CountReviews(storeID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.SideMenuReview{}).Where("store_id = ?", storeID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// findStoreByName 店舗名または別名が正規化して一致する店舗を探す（見つからない場合は nil, nil）
+func findStoreByName(db *gorm.DB, name string) (*entity.Store, error) {
+	normalized := entity.NormalizeStoreName(name)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	var store entity.Store
+	err := db.Preload("Aliases").
+		Where("normalized_name = ? OR id IN (SELECT store_id FROM store_aliases WHERE normalized_name = ?)", normalized, normalized).
+		First(&store).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// findOrCreateStoreByName 一致する店舗がなければ店舗名だけの店舗を作成する
+// 同時に同じ名前で作成された場合は、先に作成された店舗を返す。
+func findOrCreateStoreByName(db *gorm.DB, name string) (*entity.Store, error) {
+	if entity.NormalizeStoreName(name) == "" {
+		return nil, errors.New("店舗名が正しくありません")
+	}
+
+	store, err := findStoreByName(db, name)
+	if err != nil || store != nil {
+		return store, err
+	}
+
+	store = &entity.Store{Name: strings.TrimSpace(name)}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(store).Error; err != nil {
+		return nil, err
+	}
+	if store.ID != 0 {
+		return store, nil
+	}
+
+	store, err = findStoreByName(db, name)
+	if err == nil && store == nil {
+		err = gorm.ErrRecordNotFound
+	}
+	return store, err
+}
+
+func storeKey(s *entity.Store) entity.Cursor {
+	return entity.Cursor{CreatedAt: s.CreatedAt, ID: s.ID}
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRevocationRepository(db *gorm.DB) repository.TokenRevocationRepository {
+	return &tokenRevocationRepository{db: db}
+}
+
+func (r *tokenRevocationRepository) RevokeToken(token *entity.RevokedToken) error {
+	// 同じjtiが既に失効済みの場合は何もしない
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "jti"}},
+		DoNothing: true,
+	}).Create(token).Error
+}
+
+func (r *tokenRevocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *tokenRevocationRepository) RevokeAllForUser(userID uint) (uint64, error) {
+	// 読み取りと更新の間に他の一括失効が割り込まないよう、1文で世代を進めて新しい値を受け取る
+	var generation uint64
+	result := r.db.Raw("UPDATE users SET token_generation = token_generation + 1 WHERE id = ? RETURNING token_generation", userID).Scan(&generation)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return generation, nil
+}
+
+func (r *tokenRevocationRepository) GetUserTokenGeneration(userID uint) (uint64, error) {
+	var generations []uint64
+	if err := r.db.Model(&entity.User{}).Where("id = ?", userID).Pluck("token_generation", &generations).Error; err != nil {
+		return 0, err
+	}
+	if len(generations) == 0 {
+		return 0, nil
+	}
+	return generations[0], nil
+}
+
+func (r *tokenRevocationRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&entity.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
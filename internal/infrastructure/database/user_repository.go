@@ -1,12 +1,20 @@
 package database
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"sidemenulab-backend/internal/domain/entity"
 	"sidemenulab-backend/internal/domain/repository"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// uniqueViolation PostgreSQL の一意制約違反のエラーコード
+const uniqueViolation = "23505"
+
 type userRepository struct {
 	db *gorm.DB
 }
@@ -16,7 +24,7 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 }
 
 func (r *userRepository) Create(user *entity.User) error {
-	return r.db.Create(user).Error
+	return userConflict(r.db.Create(user).Error)
 }
 
 func (r *userRepository) GetByEmail(email string) (*entity.User, error) {
@@ -37,8 +45,55 @@ func (r *userRepository) GetByID(id uint) (*entity.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) GetByHandle(handle string) (*entity.User, error) {
+	var user entity.User
+	err := r.db.Where("handle = ?", handle).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) GetByHandles(handles []string) ([]*entity.User, error) {
+	var users []*entity.User
+	if len(handles) == 0 {
+		return users, nil
+	}
+	if err := r.db.Where("handle IN ?", handles).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) SearchByHandlePrefix(prefix string, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	// ハンドルに含まれる _ は LIKE のワイルドカードなのでエスケープする
+	// （prefix はユースケースでハンドルに使える文字だけであることを確認済み）
+	pattern := strings.ReplaceAll(prefix, "_", `\_`) + "%"
+	if err := r.db.Where("handle LIKE ?", pattern).Order("handle ASC").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *userRepository) Update(user *entity.User) error {
-	return r.db.Save(user).Error
+	return userConflict(r.db.Save(user).Error)
+}
+
+// userConflict メールアドレス・ハンドルの一意制約違反を ErrConflict に変換する
+// 事前の重複チェックと保存の間に同じ値で登録された場合に、500 ではなく 409 を返すため。
+func userConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case "idx_users_handle":
+		return fmt.Errorf("%w: このハンドルは既に使用されています", entity.ErrConflict)
+	case "idx_users_email":
+		return fmt.Errorf("%w: このメールアドレスは既に使用されています", entity.ErrConflict)
+	}
+	return fmt.Errorf("%w: %s", entity.ErrConflict, pgErr.Message)
 }
 
 func (r *userRepository) Delete(id uint) error {
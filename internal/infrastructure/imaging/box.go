@@ -0,0 +1,90 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidBox = errors.New("HEICのボックス構造が正しくありません")
+
+// box ISOBMFF（HEIFの格納形式）のボックス
+type box struct {
+	typ  string
+	body []byte
+	// start ファイル先頭から本体までのバイト数
+	start int
+}
+
+// fullBoxes 本体がバージョンとフラグ（4バイト）で始まり、その後に子ボックスが続くボックス
+var fullBoxes = map[string]bool{"meta": true}
+
+// readBoxes data に並んだボックスを読み取る（base は data のファイル先頭からの位置）
+func readBoxes(data []byte, base int) ([]*box, error) {
+	var boxes []*box
+	for offset := 0; offset < len(data); {
+		if len(data)-offset < 8 {
+			return nil, errInvalidBox
+		}
+		size := uint64(binary.BigEndian.Uint32(data[offset:]))
+		typ := string(data[offset+4 : offset+8])
+		header := 8
+		switch size {
+		case 0:
+			// 0 はファイルの末尾まで
+			size = uint64(len(data) - offset)
+		case 1:
+			// 1 はヘッダーの後ろの64ビットの値が大きさ
+			if len(data)-offset < 16 {
+				return nil, errInvalidBox
+			}
+			size = binary.BigEndian.Uint64(data[offset+8:])
+			header = 16
+		}
+		if size < uint64(header) || size > uint64(len(data)-offset) {
+			return nil, errInvalidBox
+		}
+
+		boxes = append(boxes, &box{
+			typ:   typ,
+			body:  data[offset+header : offset+int(size)],
+			start: base + offset + header,
+		})
+		offset += int(size)
+	}
+	return boxes, nil
+}
+
+// children 子ボックスを読み取る
+func (b *box) children() ([]*box, error) {
+	body, start := b.body, b.start
+	if fullBoxes[b.typ] {
+		if len(body) < 4 {
+			return nil, errInvalidBox
+		}
+		body, start = body[4:], start+4
+	}
+	return readBoxes(body, start)
+}
+
+func findBox(boxes []*box, typ string) *box {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b
+		}
+	}
+	return nil
+}
+
+// findPath 子ボックスを types の順にたどる
+func findPath(b *box, types ...string) (*box, error) {
+	for _, typ := range types {
+		children, err := b.children()
+		if err != nil {
+			return nil, err
+		}
+		if b = findBox(children, typ); b == nil {
+			return nil, errInvalidBox
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,52 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// exifHeader JPEGのAPP1セグメント等でTIFF形式のEXIFの前に置かれる識別子
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifOrientationTag 向きを表すEXIFのタグ
+const exifOrientationTag = 0x0112
+
+// exifOrientation EXIFから向き（1〜8）を読み取る（読み取れない場合は 1 = 回転なし）
+// data はTIFF形式のEXIF。先頭に "Exif\0\0" が付いていても構わない。
+func exifOrientation(data []byte) int {
+	tiff := bytes.TrimPrefix(data, exifHeader)
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		// SHORT 1個の値は値フィールドの先頭2バイトに入る
+		if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+			return orientation
+		}
+		return 1
+	}
+	return 1
+}
@@ -0,0 +1,96 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"slices"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// HEIC（HEIF）は画素データを展開できないため、ヘッダーから形式と大きさを読み取るだけのデコーダーを登録する。
+// アップロードは受け付けないが、対応していない形式として一律に扱わず、変換してからアップロードするよう案内するために判別する。
+
+// heicBrands HEICとして扱う ftyp のブランド
+var heicBrands = []string{"heic", "heix", "hevc", "hevx", "heim", "heis"}
+
+// heifBrands HEIF全般のブランド（AVIF等と共用のため、互換ブランドに HEIC のブランドがある場合のみ受け付ける）
+var heifBrands = []string{"mif1", "msf1"}
+
+var errHEICDecodeUnsupported = errors.New("HEIC画像の展開には対応していません")
+
+func init() {
+	for _, brand := range slices.Concat(heicBrands, heifBrands) {
+		image.RegisterFormat("heic", "????ftyp"+brand, decodeHEIC, decodeHEICConfig)
+	}
+}
+
+func decodeHEIC(io.Reader) (image.Image, error) {
+	return nil, errHEICDecodeUnsupported
+}
+
+// decodeHEICConfig meta/iprp/ipco の ispe（画像の大きさ）のうち最も大きいものを返す
+// 主画像がタイル分割されている場合もタイル全体の大きさを持つ ispe があるため、最大のものを採用すれば上限の確認には十分。
+func decodeHEICConfig(r io.Reader) (image.Config, error) {
+	data, err := io.ReadAll(io.LimitReader(r, entity.MaxReviewImageBytes+1))
+	if err != nil {
+		return image.Config{}, err
+	}
+
+	boxes, err := readBoxes(data, 0)
+	if err != nil {
+		return image.Config{}, err
+	}
+	ftyp := findBox(boxes, "ftyp")
+	if ftyp == nil || !isHEICBrand(ftyp.body) {
+		return image.Config{}, image.ErrFormat
+	}
+
+	meta := findBox(boxes, "meta")
+	if meta == nil {
+		return image.Config{}, errInvalidBox
+	}
+	ipco, err := findPath(meta, "iprp", "ipco")
+	if err != nil {
+		return image.Config{}, err
+	}
+	children, err := ipco.children()
+	if err != nil {
+		return image.Config{}, err
+	}
+
+	config := image.Config{ColorModel: color.YCbCrModel}
+	for _, child := range children {
+		if child.typ != "ispe" || len(child.body) < 12 {
+			continue
+		}
+		width := int(binary.BigEndian.Uint32(child.body[4:8]))
+		height := int(binary.BigEndian.Uint32(child.body[8:12]))
+		if width*height > config.Width*config.Height {
+			config.Width, config.Height = width, height
+		}
+	}
+	if config.Width == 0 {
+		return image.Config{}, errInvalidBox
+	}
+	return config, nil
+}
+
+// isHEICBrand ftyp の本体（major_brand, minor_version, compatible_brands...）がHEICを示すか
+func isHEICBrand(ftyp []byte) bool {
+	if len(ftyp) < 8 {
+		return false
+	}
+	if slices.Contains(heicBrands, string(ftyp[0:4])) {
+		return true
+	}
+	for i := 8; i+4 <= len(ftyp); i += 4 {
+		if slices.Contains(heicBrands, string(ftyp[i:i+4])) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"mime"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+
+	_ "golang.org/x/image/webp"
+)
+
+// Info 画像の形式と大きさ
+type Info struct {
+	Format string
+	Width  int
+	Height int
+}
+
+// supportedFormat 受け付ける形式の拡張子とMIMEタイプ
+type supportedFormat struct {
+	extensions []string
+	mimeTypes  []string
+}
+
+// supportedFormats image.DecodeConfig が返す形式名ごとの拡張子とMIMEタイプ
+var supportedFormats = map[string]supportedFormat{
+	"jpeg": {extensions: []string{".jpg", ".jpeg"}, mimeTypes: []string{"image/jpeg", "image/jpg", "image/pjpeg"}},
+	"png":  {extensions: []string{".png"}, mimeTypes: []string{"image/png"}},
+	"gif":  {extensions: []string{".gif"}, mimeTypes: []string{"image/gif"}},
+	"webp": {extensions: []string{".webp"}, mimeTypes: []string{"image/webp"}},
+	// HEIC は形式と大きさの判別のみ（多くのブラウザで表示できないため、アップロードは Validate で拒否する）
+	"heic": {},
+}
+
+// Inspect 先頭のバイト列から形式を判別し、ヘッダーから幅と高さを読み取って上限を確認する
+// 画素データは展開しないため、展開すると巨大になる画像でもメモリを消費しない。
+func Inspect(data []byte) (*Info, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, fmt.Errorf("%w: 対応していない形式です（JPEG / PNG / GIF / WebP のみ）", entity.ErrInvalidImage)
+		}
+		return nil, fmt.Errorf("%w: 画像のヘッダーを読み取れません", entity.ErrInvalidImage)
+	}
+	if _, ok := supportedFormats[format]; !ok {
+		return nil, fmt.Errorf("%w: 対応していない形式です（JPEG / PNG / GIF / WebP のみ）", entity.ErrInvalidImage)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("%w: 画像の大きさを読み取れません", entity.ErrInvalidImage)
+	}
+	if config.Width > entity.MaxReviewImageDimension || config.Height > entity.MaxReviewImageDimension {
+		return nil, fmt.Errorf("%w: 画像が大きすぎます（幅・高さは%dピクセル以下にしてください）", entity.ErrInvalidImage, entity.MaxReviewImageDimension)
+	}
+	if config.Width*config.Height > entity.MaxReviewImagePixels {
+		return nil, fmt.Errorf("%w: 画像の画素数が多すぎます（%d万画素以下にしてください）", entity.ErrInvalidImage, entity.MaxReviewImagePixels/10000)
+	}
+
+	return &Info{
+		Format: format,
+		Width:  config.Width,
+		Height: config.Height,
+	}, nil
+}
+
+// Validate Inspect に加えて、ファイル名の拡張子と申告されたContent-Typeが内容と一致するか確認する
+// Content-Type が空または application/octet-stream の場合は申告なしとして扱う。
+func Validate(data []byte, fileName string, contentType string) (*Info, error) {
+	info, err := Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+	if info.Format == "heic" {
+		return nil, fmt.Errorf("%w: HEIC / HEIF 画像には対応していません（JPEG に変換してアップロードしてください）", entity.ErrInvalidImage)
+	}
+	format := supportedFormats[info.Format]
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == "" {
+		return nil, fmt.Errorf("%w: ファイル名に拡張子がありません", entity.ErrInvalidImage)
+	}
+	if !slices.Contains(format.extensions, ext) {
+		return nil, fmt.Errorf("%w: 拡張子 %s がファイルの内容（%s）と一致しません", entity.ErrInvalidImage, ext, info.Format)
+	}
+
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, fmt.Errorf("%w: Content-Type %s が正しくありません", entity.ErrInvalidImage, contentType)
+		}
+		if mediaType != "application/octet-stream" && !slices.Contains(format.mimeTypes, mediaType) {
+			return nil, fmt.Errorf("%w: Content-Type %s がファイルの内容（%s）と一致しません", entity.ErrInvalidImage, mediaType, info.Format)
+		}
+	}
+
+	return info, nil
+}
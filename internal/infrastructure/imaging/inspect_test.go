@@ -0,0 +1,116 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// pngHeader 幅と高さだけを申告するPNG（Inspect はヘッダーしか読まないため画素データは不要）
+func pngHeader(width, height uint32) []byte {
+	ihdr := binary.BigEndian.AppendUint32(nil, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	b := []byte("\x89PNG\r\n\x1a\n")
+	b = append(b, pngChunk("IHDR", ihdr)...)
+	return append(b, pngChunk("IEND", nil)...)
+}
+
+// heicHeader 大きさ（ispe）だけを持つHEIC（画像データは不要）
+func heicHeader() []byte {
+	box := func(typ string, body ...[]byte) []byte {
+		b := bytes.Join(body, nil)
+		return append(append(binary.BigEndian.AppendUint32(nil, uint32(8+len(b))), typ...), b...)
+	}
+	fullBox := []byte{0, 0, 0, 0}
+	ispe := binary.BigEndian.AppendUint32(bytes.Clone(fullBox), 4032)
+	ispe = binary.BigEndian.AppendUint32(ispe, 3024)
+	return append(
+		box("ftyp", []byte("heic\x00\x00\x00\x00mif1heic")),
+		box("meta", fullBox, box("iprp", box("ipco", box("ispe", ispe))))...,
+	)
+}
+
+func TestValidate(t *testing.T) {
+	jpegData := jpegWithMetadata(t, testImage(16, 8), 1)
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, testImage(3, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		fileName    string
+		contentType string
+		format      string
+	}{
+		{name: "JPEG", data: jpegData, fileName: "photo.jpg", contentType: "image/jpeg", format: "jpeg"},
+		{name: "拡張子が大文字", data: jpegData, fileName: "PHOTO.JPEG", contentType: "image/jpeg", format: "jpeg"},
+		{name: "Content-Type の申告なし", data: jpegData, fileName: "photo.jpg", contentType: "", format: "jpeg"},
+		{name: "application/octet-stream", data: jpegData, fileName: "photo.jpg", contentType: "application/octet-stream", format: "jpeg"},
+		{name: "パラメータ付きの Content-Type", data: pngData.Bytes(), fileName: "image.png", contentType: "image/png; name=image.png", format: "png"},
+		{name: "上限ちょうどの大きさ", data: pngHeader(entity.MaxReviewImageDimension, entity.MaxReviewImagePixels/entity.MaxReviewImageDimension), fileName: "large.png", contentType: "image/png", format: "png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Validate(tt.data, tt.fileName, tt.contentType)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if info.Format != tt.format {
+				t.Errorf("Format = %s, want %s", info.Format, tt.format)
+			}
+		})
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	jpegData := jpegWithMetadata(t, testImage(16, 8), 1)
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, testImage(3, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		fileName    string
+		contentType string
+		// message エラーメッセージに含まれるべき文字列（どの検証で拒否されたかの確認）
+		message string
+	}{
+		{name: "PNGにJPEGの拡張子", data: pngData.Bytes(), fileName: "image.jpg", contentType: "image/png", message: "拡張子 .jpg"},
+		{name: "JPEGにPNGの拡張子", data: jpegData, fileName: "photo.png", contentType: "image/jpeg", message: "拡張子 .png"},
+		{name: "拡張子なし", data: jpegData, fileName: "photo", contentType: "image/jpeg", message: "拡張子がありません"},
+		{name: "JPEGにPNGの Content-Type", data: jpegData, fileName: "photo.jpg", contentType: "image/png", message: "Content-Type image/png"},
+		{name: "画像以外の Content-Type", data: jpegData, fileName: "photo.jpg", contentType: "text/html", message: "Content-Type text/html"},
+		{name: "不正な Content-Type", data: jpegData, fileName: "photo.jpg", contentType: "image/", message: "正しくありません"},
+		{name: "幅が上限を超える", data: pngHeader(entity.MaxReviewImageDimension+1, 1), fileName: "wide.png", contentType: "image/png", message: "画像が大きすぎます"},
+		{name: "高さが上限を超える", data: pngHeader(1, entity.MaxReviewImageDimension+1), fileName: "tall.png", contentType: "image/png", message: "画像が大きすぎます"},
+		{name: "画素数が上限を超える", data: pngHeader(entity.MaxReviewImageDimension, entity.MaxReviewImagePixels/entity.MaxReviewImageDimension+1), fileName: "large.png", contentType: "image/png", message: "画素数が多すぎます"},
+		{name: "大きさが0", data: pngHeader(0, 10), fileName: "empty.png", contentType: "image/png", message: ""},
+		{name: "HEIC", data: heicHeader(), fileName: "photo.heic", contentType: "image/heic", message: "HEIC"},
+		{name: "対応していない形式", data: []byte("BM\x00\x00\x00\x00"), fileName: "image.bmp", contentType: "image/bmp", message: "対応していない形式"},
+		{name: "途中で切れたヘッダー", data: pngHeader(16, 16)[:20], fileName: "broken.png", contentType: "image/png", message: "ヘッダーを読み取れません"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Validate(tt.data, tt.fileName, tt.contentType)
+			if !errors.Is(err, entity.ErrInvalidImage) {
+				t.Fatalf("Validate() error = %v, want ErrInvalidImage", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Validate() error = %v, want %q を含むメッセージ", err, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"slices"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// reencodeJPEGQuality 向きを補正して再エンコードするときのJPEGの品質
+const reencodeJPEGQuality = 92
+
+// maxReorientPixels 向きを補正する画像の画素数の上限
+// 画素を展開した元の画像と回転先の画像を同時にメモリに持つため、アップロードの上限（MaxReviewImagePixels）より小さくする。
+const maxReorientPixels = 24 * 1000 * 1000
+
+var errInvalidStructure = errors.New("画像の構造が正しくありません")
+
+// Sanitize 位置情報などのメタデータ（EXIF / XMP / IPTC / コメント）を取り除いた画像を返す
+// 画素データは再エンコードせずにそのまま残す。ただし EXIF の向き（Orientation）が回転・反転を示す場合は、
+// EXIF を取り除くと向きが失われるため、画素を回転してから再エンコードする（WebP はエンコーダーがないため PNG にする）。
+func Sanitize(data []byte, format string) ([]byte, error) {
+	var sanitized []byte
+	orientation := 1
+	var err error
+
+	switch format {
+	case "jpeg":
+		sanitized, orientation, err = sanitizeJPEG(data)
+	case "png":
+		sanitized, orientation, err = sanitizePNG(data)
+	case "gif":
+		sanitized, err = sanitizeGIF(data)
+	case "webp":
+		sanitized, orientation, err = sanitizeWebP(data)
+	default:
+		return nil, fmt.Errorf("%w: 形式 %s のメタデータの除去には対応していません", entity.ErrInvalidImage, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: メタデータの除去に失敗しました（%v）", entity.ErrInvalidImage, err)
+	}
+
+	if orientation == 1 {
+		return sanitized, nil
+	}
+	return reorient(sanitized, format, orientation)
+}
+
+// reorient 画素を向きに合わせて回転・反転し、メタデータなしで再エンコードする
+// JPEG の場合は、表示の色が変わらないよう ICC プロファイル（APP2）を引き継ぐ。
+func reorient(data []byte, format string, orientation int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: 画像の向きを補正できません", entity.ErrInvalidImage)
+	}
+	if config.Width*config.Height > maxReorientPixels {
+		return nil, fmt.Errorf("%w: 向きの補正が必要な画像は%d万画素以下にしてください", entity.ErrInvalidImage, maxReorientPixels/10000)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: 画像の向きを補正できません", entity.ErrInvalidImage)
+	}
+	dst := applyOrientation(src, orientation)
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: reencodeJPEGQuality})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("画像の再エンコードに失敗しました: %w", err)
+	}
+
+	out := buf.Bytes()
+	if format == "jpeg" {
+		if icc := jpegICCSegments(data); len(icc) > 0 {
+			// SOI の直後に挿入する
+			out = slices.Concat(out[:2], icc, out[2:])
+		}
+	}
+	// 再エンコードで元より大きくなることがあるため、アップロードの上限を改めて確認する
+	if len(out) > entity.MaxReviewImageBytes {
+		return nil, fmt.Errorf("%w: 向きを補正した画像が大きすぎます（%dMB以下にしてください）", entity.ErrInvalidImage, entity.MaxReviewImageBytes/1024/1024)
+	}
+	return out, nil
+}
+
+// applyOrientation EXIFの向き（2〜8）が示す回転・反転を画素に適用する
+// 元の画像は1行ずつ変換して回転先へ書き込み、画像全体の大きさのバッファは回転先の1枚だけにする。
+func applyOrientation(src image.Image, orientation int) *image.NRGBA {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	row := image.NewNRGBA(image.Rect(0, 0, w, 1))
+
+	for y := 0; y < h; y++ {
+		draw.Draw(row, row.Bounds(), src, image.Pt(bounds.Min.X, bounds.Min.Y+y), draw.Src)
+		for x := 0; x < w; x++ {
+			dx, dy := x, y
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = w-1-x, y
+			case 3: // 180度回転
+				dx, dy = w-1-x, h-1-y
+			case 4: // 上下反転
+				dx, dy = x, h-1-y
+			case 5: // 左上と右下を結ぶ対角線で反転
+				dx, dy = y, x
+			case 6: // 時計回りに90度回転
+				dx, dy = h-1-y, x
+			case 7: // 右上と左下を結ぶ対角線で反転
+				dx, dy = h-1-y, w-1-x
+			case 8: // 反時計回りに90度回転
+				dx, dy = y, w-1-x
+			}
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], row.Pix[x*4:x*4+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,107 @@
+package imaging
+
+import "slices"
+
+// GIFのブロック
+const (
+	gifExtension        = 0x21
+	gifImageDescriptor  = 0x2C
+	gifTrailer          = 0x3B
+	gifCommentLabel     = 0xFE
+	gifApplicationLabel = 0xFF
+)
+
+// gifAnimationApplications 残すアプリケーション拡張（アニメーションのループ回数の指定）
+var gifAnimationApplications = []string{"NETSCAPE2.0", "ANIMEXTS1.0"}
+
+// sanitizeGIF コメント拡張と、アニメーション以外のアプリケーション拡張（XMP 等）を取り除く
+// トレーラー以降のデータも取り除く。
+func sanitizeGIF(data []byte) ([]byte, error) {
+	// ヘッダー（6バイト）と論理画面記述子（7バイト）
+	if len(data) < 13 || (string(data[0:6]) != "GIF87a" && string(data[0:6]) != "GIF89a") {
+		return nil, errInvalidStructure
+	}
+	offset := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		offset += 3 << ((flags & 0x07) + 1)
+	}
+	if offset > len(data) {
+		return nil, errInvalidStructure
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:offset]...)
+
+	for offset < len(data) {
+		start := offset
+		switch data[offset] {
+		case gifTrailer:
+			return append(out, gifTrailer), nil
+
+		case gifExtension:
+			if offset+2 > len(data) {
+				return nil, errInvalidStructure
+			}
+			label := data[offset+1]
+			end, err := skipGIFSubBlocks(data, offset+2)
+			if err != nil {
+				return nil, err
+			}
+			offset = end
+			if keepGIFExtension(label, data[start+2:end]) {
+				out = append(out, data[start:end]...)
+			}
+
+		case gifImageDescriptor:
+			// 画像記述子（10バイト）、ローカルカラーテーブル、LZWの最小符号長（1バイト）、画像データ
+			if offset+10 > len(data) {
+				return nil, errInvalidStructure
+			}
+			offset += 10
+			if flags := data[offset-1]; flags&0x80 != 0 {
+				offset += 3 << ((flags & 0x07) + 1)
+			}
+			end, err := skipGIFSubBlocks(data, offset+1)
+			if err != nil {
+				return nil, err
+			}
+			offset = end
+			out = append(out, data[start:end]...)
+
+		default:
+			return nil, errInvalidStructure
+		}
+	}
+	return nil, errInvalidStructure
+}
+
+// skipGIFSubBlocks offset から始まるサブブロックの並び（大きさ 0 のブロックで終わる）の次の位置を返す
+func skipGIFSubBlocks(data []byte, offset int) (int, error) {
+	for {
+		if offset >= len(data) {
+			return 0, errInvalidStructure
+		}
+		size := int(data[offset])
+		offset++
+		if size == 0 {
+			return offset, nil
+		}
+		offset += size
+	}
+}
+
+// keepGIFExtension blocks は拡張のラベルの後ろのサブブロックの並び
+func keepGIFExtension(label byte, blocks []byte) bool {
+	switch label {
+	case gifCommentLabel:
+		return false
+	case gifApplicationLabel:
+		// 最初のサブブロックはアプリケーション識別子（8バイト）と認証コード（3バイト）
+		if len(blocks) < 12 || blocks[0] != 11 {
+			return false
+		}
+		return slices.Contains(gifAnimationApplications, string(blocks[1:12]))
+	default:
+		return true
+	}
+}
@@ -0,0 +1,126 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// JPEGのマーカー
+const (
+	jpegSOI   = 0xD8
+	jpegEOI   = 0xD9
+	jpegSOS   = 0xDA
+	jpegAPP0  = 0xE0
+	jpegAPP1  = 0xE1
+	jpegAPP2  = 0xE2
+	jpegAPP14 = 0xEE
+	jpegAPP15 = 0xEF
+	jpegCOM   = 0xFE
+)
+
+// iccProfileHeader ICCプロファイルを格納するAPP2セグメントの識別子
+var iccProfileHeader = []byte("ICC_PROFILE\x00")
+
+// sanitizeJPEG APPnセグメントとコメントを取り除き、EXIFの向きを返す
+// 残すのは JFIF（APP0）、ICCプロファイル（APP2）、Adobe（APP14、色変換の指定）のみ。
+// EOI 以降に連結された画像（MPFのプレビュー等、EXIFを含むことがある）も取り除く。
+func sanitizeJPEG(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegSOI {
+		return nil, 0, errInvalidStructure
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, jpegSOI)
+	orientation := 1
+
+	for offset := 2; ; {
+		// マーカーの前の埋め草（0xFF の連続）を読み飛ばす
+		for offset < len(data) && data[offset] == 0xFF && offset+1 < len(data) && data[offset+1] == 0xFF {
+			offset++
+		}
+		if offset+2 > len(data) || data[offset] != 0xFF {
+			return nil, 0, errInvalidStructure
+		}
+		marker := data[offset+1]
+
+		switch {
+		case marker == jpegEOI:
+			return append(out, 0xFF, jpegEOI), orientation, nil
+		case marker >= 0xD0 && marker <= 0xD7, marker == 0x01:
+			// 長さを持たないマーカー
+			out = append(out, 0xFF, marker)
+			offset += 2
+			continue
+		}
+
+		if offset+4 > len(data) {
+			return nil, 0, errInvalidStructure
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		end := offset + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, 0, errInvalidStructure
+		}
+		segment := data[offset:end]
+		payload := data[offset+4 : end]
+
+		if marker == jpegAPP1 && bytes.HasPrefix(payload, exifHeader) && orientation == 1 {
+			orientation = exifOrientation(payload)
+		}
+		if keepJPEGSegment(marker, payload) {
+			out = append(out, segment...)
+		}
+		offset = end
+
+		if marker == jpegSOS {
+			// スキャンのデータは次のマーカー（0xFF00 のバイト詰めとリスタートマーカーを除く）まで続く
+			scanEnd := offset
+			for ; scanEnd+1 < len(data); scanEnd++ {
+				if data[scanEnd] == 0xFF && data[scanEnd+1] != 0x00 && (data[scanEnd+1] < 0xD0 || data[scanEnd+1] > 0xD7) {
+					break
+				}
+			}
+			if scanEnd+1 >= len(data) {
+				return nil, 0, errInvalidStructure
+			}
+			out = append(out, data[offset:scanEnd]...)
+			offset = scanEnd
+		}
+	}
+}
+
+func keepJPEGSegment(marker byte, payload []byte) bool {
+	switch {
+	case marker == jpegCOM:
+		return false
+	case marker == jpegAPP0, marker == jpegAPP14:
+		return true
+	case marker == jpegAPP2:
+		return bytes.HasPrefix(payload, iccProfileHeader)
+	case marker >= jpegAPP0 && marker <= jpegAPP15:
+		return false
+	default:
+		return true
+	}
+}
+
+// jpegICCSegments 最初のスキャンより前にあるICCプロファイルのAPP2セグメントを、順序を保って連結して返す
+// sanitizeJPEG で構造を確認済みのデータに使う。
+func jpegICCSegments(data []byte) []byte {
+	var segments []byte
+	for offset := 2; offset+4 <= len(data) && data[offset] == 0xFF; {
+		marker := data[offset+1]
+		if marker == jpegSOS || marker == jpegEOI {
+			break
+		}
+		end := offset + 2 + int(binary.BigEndian.Uint16(data[offset+2:]))
+		if end > len(data) {
+			break
+		}
+		if marker == jpegAPP2 && bytes.HasPrefix(data[offset+4:end], iccProfileHeader) {
+			segments = append(segments, data[offset:end]...)
+		}
+		offset = end
+	}
+	return segments
+}
@@ -0,0 +1,53 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks 取り除くチャンク（EXIF、テキスト（XMP を含む）、更新日時）
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// sanitizePNG メタデータのチャンクを取り除き、EXIFの向きを返す
+// チャンクはCRCごと複製するため、残したチャンクの内容は変わらない。IEND 以降のデータは取り除く。
+func sanitizePNG(data []byte) ([]byte, int, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, 0, errInvalidStructure
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	orientation := 1
+
+	for offset := len(pngSignature); offset < len(data); {
+		if offset+12 > len(data) {
+			return nil, 0, errInvalidStructure
+		}
+		length := int(binary.BigEndian.Uint32(data[offset:]))
+		end := offset + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, 0, errInvalidStructure
+		}
+		typ := string(data[offset+4 : offset+8])
+
+		if typ == "eXIf" {
+			orientation = exifOrientation(data[offset+8 : offset+8+length])
+		}
+		if !pngMetadataChunks[typ] {
+			out = append(out, data[offset:end]...)
+		}
+		if typ == "IEND" {
+			return out, orientation, nil
+		}
+		offset = end
+	}
+	return nil, 0, errInvalidStructure
+}
@@ -0,0 +1,475 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// テスト用のEXIFに入れる位置情報（東京都千代田区付近）
+var (
+	gpsLatitude  = rationals(35, 1, 40, 1, 5234, 100)
+	gpsLongitude = rationals(139, 1, 45, 1, 1123, 100)
+)
+
+// xmpPacket 位置情報を含むXMP
+const xmpPacket = `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+	`<rdf:Description xmlns:exif="http://ns.adobe.com/exif/1.0/" exif:GPSLatitude="35,40.8723N" exif:GPSLongitude="139,45.1871E"/>` +
+	`</rdf:RDF></x:xmpmeta>`
+
+func rationals(values ...uint32) []byte {
+	b := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(b[4*i:], v)
+	}
+	return b
+}
+
+// buildExif 向きと位置情報（GPS IFD）を持つTIFF形式のEXIFを作る
+func buildExif(orientation uint16) []byte {
+	const (
+		ifd0Offset = 8
+		gpsOffset  = ifd0Offset + 2 + 2*12 + 4
+		dataOffset = gpsOffset + 2 + 4*12 + 4
+	)
+	var b bytes.Buffer
+	le := binary.LittleEndian
+	write := func(v any) { binary.Write(&b, le, v) }
+	entry := func(tag, typ uint16, count uint32, value []byte) {
+		write(tag)
+		write(typ)
+		write(count)
+		b.Write(append(value, make([]byte, 4-len(value))...))
+	}
+	u32 := func(v uint32) []byte { return le.AppendUint32(nil, v) }
+
+	b.WriteString("II")
+	write(uint16(42))
+	write(uint32(ifd0Offset))
+
+	write(uint16(2))
+	entry(exifOrientationTag, 3, 1, le.AppendUint16(nil, orientation))
+	entry(0x8825, 4, 1, u32(gpsOffset))
+	write(uint32(0))
+
+	write(uint16(4))
+	entry(0x0001, 2, 2, []byte("N\x00"))
+	entry(0x0002, 5, 3, u32(dataOffset))
+	entry(0x0003, 2, 2, []byte("E\x00"))
+	entry(0x0004, 5, 3, u32(dataOffset+24))
+	write(uint32(0))
+
+	b.Write(gpsLatitude)
+	b.Write(gpsLongitude)
+	return b.Bytes()
+}
+
+// testImage 左上の1画素だけ赤く、残りが青い画像
+func testImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{B: 255, A: 255})
+		}
+	}
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	return img
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	b := []byte{0xFF, marker}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(payload)+2))
+	return append(b, payload...)
+}
+
+// jpegWithMetadata EXIF・XMP・コメントを持ち、EOI の後ろにEXIF付きのプレビューが連結されたJPEG
+func jpegWithMetadata(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	exif := jpegSegment(jpegAPP1, append(bytes.Clone(exifHeader), buildExif(orientation)...))
+
+	var b bytes.Buffer
+	b.Write(encoded.Bytes()[:2])
+	b.Write(exif)
+	b.Write(jpegSegment(jpegAPP1, []byte("http://ns.adobe.com/xap/1.0/\x00"+xmpPacket)))
+	b.Write(jpegSegment(jpegCOM, []byte("自宅で撮影")))
+	b.Write(encoded.Bytes()[2:])
+	// MPF形式のプレビュー画像
+	b.Write([]byte{0xFF, jpegSOI})
+	b.Write(exif)
+	b.Write([]byte{0xFF, jpegEOI})
+	return b.Bytes()
+}
+
+func pngChunk(typ string, data []byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	b = append(b, typ...)
+	b = append(b, data...)
+	return binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(b[4:]))
+}
+
+// pngWithMetadata eXIf・XMP（iTXt）・テキストを持つPNG
+func pngWithMetadata(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatal(err)
+	}
+	// シグネチャ（8バイト）と IHDR（25バイト）の後ろに挿入する
+	const ihdrEnd = 8 + 25
+	var b bytes.Buffer
+	b.Write(encoded.Bytes()[:ihdrEnd])
+	b.Write(pngChunk("eXIf", buildExif(orientation)))
+	b.Write(pngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"+xmpPacket)))
+	b.Write(pngChunk("tEXt", []byte("Comment\x00自宅で撮影")))
+	b.Write(encoded.Bytes()[ihdrEnd:])
+	return b.Bytes()
+}
+
+// webpVP8L 1×1の可逆圧縮WebPの VP8L チャンク
+func webpVP8L(t *testing.T) []byte {
+	t.Helper()
+	simple, err := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return simple[12:]
+}
+
+func webpChunk(fourCC string, data []byte) []byte {
+	b := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+	b = append(b, data...)
+	if len(data)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// webpWithMetadata EXIF・XMP チャンクを持つ拡張形式（VP8X）のWebP
+func webpWithMetadata(t *testing.T, orientation uint16) []byte {
+	t.Helper()
+	vp8x := make([]byte, 10)
+	vp8x[0] = webpFlagEXIF | webpFlagXMP
+
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	body.Write(webpChunk("VP8X", vp8x))
+	body.Write(webpVP8L(t))
+	body.Write(webpChunk("EXIF", buildExif(orientation)))
+	body.Write(webpChunk("XMP ", []byte(xmpPacket)))
+
+	b := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(body.Len()))...)
+	return append(b, body.Bytes()...)
+}
+
+func gifSubBlocks(data []byte) []byte {
+	var b []byte
+	for len(data) > 0 {
+		n := min(len(data), 255)
+		b = append(b, byte(n))
+		b = append(b, data[:n]...)
+		data = data[n:]
+	}
+	return append(b, 0)
+}
+
+// gifWithMetadata コメント拡張・XMPのアプリケーション拡張・ループ回数の指定を持つGIF
+func gifWithMetadata(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewPaletted(image.Rect(0, 0, 4, 4), color.Palette{color.Black, color.White})
+	var encoded bytes.Buffer
+	if err := gif.Encode(&encoded, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	data := encoded.Bytes()
+	headerEnd := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		headerEnd += 3 << ((flags & 0x07) + 1)
+	}
+
+	var b bytes.Buffer
+	b.Write(data[:headerEnd])
+	b.Write([]byte{gifExtension, gifApplicationLabel, 11})
+	b.WriteString("NETSCAPE2.0")
+	b.Write([]byte{3, 1, 0, 0, 0})
+	b.Write([]byte{gifExtension, gifCommentLabel})
+	b.Write(gifSubBlocks([]byte("自宅で撮影")))
+	b.Write([]byte{gifExtension, gifApplicationLabel, 11})
+	b.WriteString("XMP DataXMP")
+	b.Write(gifSubBlocks([]byte(xmpPacket)))
+	b.Write(data[headerEnd:])
+	return b.Bytes()
+}
+
+// assertNoMetadata 位置情報とEXIF・XMPが残っていないことを確認する
+func assertNoMetadata(t *testing.T, data []byte) {
+	t.Helper()
+	for name, marker := range map[string][]byte{
+		"GPSの緯度":        gpsLatitude,
+		"GPSの経度":        gpsLongitude,
+		"XMPの位置情報":      []byte("GPSLatitude"),
+		"コメント・テキスト":     []byte("自宅で撮影"),
+		"XMPの名前空間":      []byte("adobe:ns:meta"),
+		"TIFFのヘッダー":     {'I', 'I', 42, 0},
+		"GPSの緯度の方角":     {0x01, 0x00, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00, 'N', 0x00},
+		"GPS IFDへのポインタ": {0x25, 0x88, 0x04, 0x00},
+	} {
+		if bytes.Contains(data, marker) {
+			t.Errorf("%sが残っています", name)
+		}
+	}
+}
+
+func decodeNRGBA(t *testing.T, data []byte) *image.NRGBA {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("メタデータを取り除いた画像をデコードできません: %v", err)
+	}
+	bounds := img.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			nrgba.Set(x, y, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return nrgba
+}
+
+func TestSanitizeRemovesGPSMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   []byte
+	}{
+		{name: "JPEG", format: "jpeg", data: jpegWithMetadata(t, testImage(16, 8), 1)},
+		{name: "PNG", format: "png", data: pngWithMetadata(t, testImage(3, 2), 1)},
+		{name: "WebP", format: "webp", data: webpWithMetadata(t, 1)},
+		{name: "GIF", format: "gif", data: gifWithMetadata(t)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 前提: 入力には位置情報が含まれている
+			if !bytes.Contains(tt.data, gpsLatitude) && !bytes.Contains(tt.data, []byte("GPSLatitude")) {
+				t.Fatal("テスト用の画像に位置情報が含まれていません")
+			}
+
+			got, err := Sanitize(tt.data, tt.format)
+			if err != nil {
+				t.Fatalf("Sanitize() error = %v", err)
+			}
+			assertNoMetadata(t, got)
+
+			info, err := Inspect(got)
+			if err != nil {
+				t.Fatalf("メタデータを取り除いた画像を判別できません: %v", err)
+			}
+			if info.Format != tt.format {
+				t.Errorf("形式 = %s, want %s", info.Format, tt.format)
+			}
+		})
+	}
+}
+
+func TestSanitizeKeepsPixelsWithoutReencoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   []byte
+		plain  func() []byte
+	}{
+		{
+			name:   "JPEG",
+			format: "jpeg",
+			data:   jpegWithMetadata(t, testImage(16, 8), 1),
+			plain: func() []byte {
+				var b bytes.Buffer
+				jpeg.Encode(&b, testImage(16, 8), &jpeg.Options{Quality: 90})
+				return b.Bytes()
+			},
+		},
+		{
+			name:   "PNG",
+			format: "png",
+			data:   pngWithMetadata(t, testImage(3, 2), 1),
+			plain: func() []byte {
+				var b bytes.Buffer
+				png.Encode(&b, testImage(3, 2))
+				return b.Bytes()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sanitize(tt.data, tt.format)
+			if err != nil {
+				t.Fatalf("Sanitize() error = %v", err)
+			}
+			// メタデータを付ける前の画像と同じバイト列になる（画素データは再エンコードされない）
+			if !bytes.Equal(got, tt.plain()) {
+				t.Error("メタデータ以外の内容が変わっています")
+			}
+		})
+	}
+}
+
+func TestSanitizeAppliesOrientation(t *testing.T) {
+	// 3×2 の画像の左上の赤い画素が、向きを補正した後にどこへ移るか
+	tests := []struct {
+		orientation   uint16
+		width, height int
+		redX, redY    int
+	}{
+		{orientation: 1, width: 3, height: 2, redX: 0, redY: 0},
+		{orientation: 2, width: 3, height: 2, redX: 2, redY: 0},
+		{orientation: 3, width: 3, height: 2, redX: 2, redY: 1},
+		{orientation: 4, width: 3, height: 2, redX: 0, redY: 1},
+		{orientation: 5, width: 2, height: 3, redX: 0, redY: 0},
+		{orientation: 6, width: 2, height: 3, redX: 1, redY: 0},
+		{orientation: 7, width: 2, height: 3, redX: 1, redY: 2},
+		{orientation: 8, width: 2, height: 3, redX: 0, redY: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("向き%d", tt.orientation), func(t *testing.T) {
+			got, err := Sanitize(pngWithMetadata(t, testImage(3, 2), tt.orientation), "png")
+			if err != nil {
+				t.Fatalf("Sanitize() error = %v", err)
+			}
+			assertNoMetadata(t, got)
+
+			img := decodeNRGBA(t, got)
+			if img.Bounds().Dx() != tt.width || img.Bounds().Dy() != tt.height {
+				t.Fatalf("大きさ = %dx%d, want %dx%d", img.Bounds().Dx(), img.Bounds().Dy(), tt.width, tt.height)
+			}
+			for y := 0; y < tt.height; y++ {
+				for x := 0; x < tt.width; x++ {
+					red := img.NRGBAAt(x, y).R == 255
+					if red != (x == tt.redX && y == tt.redY) {
+						t.Errorf("(%d, %d) の画素の色が正しくありません", x, y)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeRotatesJPEG(t *testing.T) {
+	got, err := Sanitize(jpegWithMetadata(t, testImage(16, 8), 6), "jpeg")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	assertNoMetadata(t, got)
+
+	info, err := Inspect(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Format != "jpeg" || info.Width != 8 || info.Height != 16 {
+		t.Errorf("Inspect() = %+v, want 8x16 の jpeg", info)
+	}
+}
+
+func TestSanitizeRotatedJPEGKeepsICCProfile(t *testing.T) {
+	data := jpegWithMetadata(t, testImage(16, 8), 6)
+	icc := jpegSegment(jpegAPP2, append(bytes.Clone(iccProfileHeader), "\x01\x01テスト用プロファイル"...))
+	data = append(append(bytes.Clone(data[:2]), icc...), data[2:]...)
+
+	got, err := Sanitize(data, "jpeg")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	assertNoMetadata(t, got)
+	if !bytes.HasPrefix(got, append([]byte{0xFF, jpegSOI}, icc...)) {
+		t.Error("回転して再エンコードしたJPEGにICCプロファイルが引き継がれていません")
+	}
+
+	info, err := Inspect(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Width != 8 || info.Height != 16 {
+		t.Errorf("Inspect() = %+v, want 8x16", info)
+	}
+}
+
+func TestSanitizeRejectsLargeImageToRotate(t *testing.T) {
+	// 画素データは不要（デコードの前に画素数で拒否する）
+	header := pngHeader(6000, 5000)
+	const ihdrEnd = 8 + 25
+	data := append(bytes.Clone(header[:ihdrEnd]), pngChunk("eXIf", buildExif(6))...)
+	data = append(data, header[ihdrEnd:]...)
+
+	_, err := Sanitize(data, "png")
+	if !errors.Is(err, entity.ErrInvalidImage) {
+		t.Fatalf("Sanitize() error = %v, want ErrInvalidImage", err)
+	}
+}
+
+func TestSanitizeReencodesRotatedWebPAsPNG(t *testing.T) {
+	got, err := Sanitize(webpWithMetadata(t, 6), "webp")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	assertNoMetadata(t, got)
+
+	info, err := Inspect(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Format != "png" {
+		t.Errorf("形式 = %s, want png", info.Format)
+	}
+}
+
+func TestSanitizeWebPClearsMetadataFlags(t *testing.T) {
+	got, err := Sanitize(webpWithMetadata(t, 1), "webp")
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	if size := int(binary.LittleEndian.Uint32(got[4:8])); size != len(got)-8 {
+		t.Errorf("RIFFの大きさ = %d, want %d", size, len(got)-8)
+	}
+	if flags := got[20]; flags&(webpFlagEXIF|webpFlagXMP) != 0 {
+		t.Errorf("VP8X のフラグ = %#x, EXIF / XMP のフラグが残っています", flags)
+	}
+	decodeNRGBA(t, got)
+}
+
+func TestSanitizeRejectsBrokenImages(t *testing.T) {
+	valid := jpegWithMetadata(t, testImage(16, 8), 1)
+	tests := []struct {
+		name   string
+		format string
+		data   []byte
+	}{
+		{name: "途中で切れたJPEG", format: "jpeg", data: valid[:len(valid)/3]},
+		{name: "JPEGではないデータ", format: "jpeg", data: []byte("not a jpeg")},
+		{name: "IENDのないPNG", format: "png", data: pngWithMetadata(t, testImage(3, 2), 1)[:60]},
+		{name: "対応していない形式", format: "bmp", data: []byte("BM")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Sanitize(tt.data, tt.format); !errors.Is(err, entity.ErrInvalidImage) {
+				t.Errorf("Sanitize() error = %v, want ErrInvalidImage", err)
+			}
+		})
+	}
+}
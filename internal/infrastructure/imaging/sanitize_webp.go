@@ -0,0 +1,60 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// VP8X チャンクのフラグ
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// sanitizeWebP EXIF / XMP チャンクを取り除き、VP8X のフラグとRIFFの大きさを合わせて直す
+func sanitizeWebP(data []byte) ([]byte, int, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, 0, errInvalidStructure
+	}
+	riffEnd := 8 + int(binary.LittleEndian.Uint32(data[4:8]))
+	if riffEnd > len(data) {
+		return nil, 0, errInvalidStructure
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:12]...)
+	orientation := 1
+
+	for offset := 12; offset < riffEnd; {
+		if offset+8 > riffEnd {
+			return nil, 0, errInvalidStructure
+		}
+		fourCC := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		// チャンクの大きさが奇数の場合は1バイトの埋め草が付く
+		end := offset + 8 + size + size%2
+		if size < 0 || offset+8+size > riffEnd {
+			return nil, 0, errInvalidStructure
+		}
+		end = min(end, riffEnd)
+
+		switch fourCC {
+		case "EXIF":
+			orientation = exifOrientation(data[offset+8 : offset+8+size])
+		case "XMP ":
+			// 取り除く
+		case "VP8X":
+			chunk := bytes.Clone(data[offset:end])
+			if size > 0 {
+				chunk[8] &^= webpFlagEXIF | webpFlagXMP
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[offset:end]...)
+		}
+		offset = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, orientation, nil
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// tokenParamPattern 本文中のURLに含まれるトークン（token クエリパラメータ）
+var tokenParamPattern = regexp.MustCompile(`([?&]token=)[^&\s]+`)
+
+// LogMailer メールを送信せずにログとファイルへ出力する（ローカル開発・テスト用）
+// ログにはパスワード再設定・メールアドレス確認のトークンを伏せて出力する。
+// トークンを含む本文が必要な場合は dir を指定してファイルに書き出す（dir が空の場合はログ出力のみ行う）。
+type LogMailer struct {
+	dir string
+}
+
+func NewLogMailer(dir string) *LogMailer {
+	return &LogMailer{dir: dir}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg *entity.MailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	log.Printf("メール送信（ログ出力） - To: %s, Subject: %s\n%s", msg.To, msg.Subject, redactTokens(msg.Body))
+
+	if m.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("メール出力ディレクトリの作成に失敗しました: %w", err)
+	}
+
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), sanitizeFileName(msg.To))
+	content := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n", msg.To, msg.Subject, msg.Body)
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(content), 0o644); err != nil {
+		return fmt.Errorf("メールの書き出しに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// redactTokens 本文中のURLのトークンを伏せる
+func redactTokens(body string) string {
+	return tokenParamPattern.ReplaceAllString(body, "${1}[REDACTED]")
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,18 @@
+package realtime
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// Subscription トピックの購読
+type Subscription interface {
+	// Events 配信されたイベント。Close を呼ぶと閉じられる
+	Events() <-chan *entity.Event
+	Close()
+}
+
+// Hub トピック単位の pub/sub インターフェース（interfaces.EventPublisher を満たす）
+// プロセス内の実装のほか、複数インスタンスで共有するメッセージブローカーの実装に差し替えられるようにする。
+type Hub interface {
+	// Publish 購読者がいなければ何もしない。配信が追いつかない購読者へのイベントは破棄する
+	Publish(topic string, event *entity.Event)
+	Subscribe(topic string) Subscription
+}
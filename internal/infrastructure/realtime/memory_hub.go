@@ -0,0 +1,84 @@
+package realtime
+
+import (
+	"log"
+	"sync"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// subscriberBuffer 購読者ごとに溜めておけるイベントの件数
+const subscriberBuffer = 32
+
+// MemoryHub プロセス内で完結する Hub の実装
+// 同じプロセスに接続している購読者にのみ配信される。
+type MemoryHub struct {
+	mu     sync.RWMutex
+	topics map[string]map[*memorySubscription]struct{}
+}
+
+func NewMemoryHub() Hub {
+	return &MemoryHub{topics: make(map[string]map[*memorySubscription]struct{})}
+}
+
+func (h *MemoryHub) Publish(topic string, event *entity.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.topics[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("購読者の受信が追いつかないためイベントを破棄しました - Topic: %s, Type: %s", topic, event.Type)
+		}
+	}
+}
+
+func (h *MemoryHub) Subscribe(topic string) Subscription {
+	sub := &memorySubscription{
+		hub:    h,
+		topic:  topic,
+		events: make(chan *entity.Event, subscriberBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[*memorySubscription]struct{})
+	}
+	h.topics[topic][sub] = struct{}{}
+	return sub
+}
+
+func (h *MemoryHub) unsubscribe(sub *memorySubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.topics[sub.topic]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.topics, sub.topic)
+	}
+	// Publish と同じロックの中で閉じるため、閉じたチャネルへ送信することはない
+	close(sub.events)
+}
+
+type memorySubscription struct {
+	hub    *MemoryHub
+	topic  string
+	events chan *entity.Event
+}
+
+func (s *memorySubscription) Events() <-chan *entity.Event {
+	return s.events
+}
+
+func (s *memorySubscription) Close() {
+	s.hub.unsubscribe(s)
+}
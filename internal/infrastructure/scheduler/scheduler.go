@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Job 定期実行する処理
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Scheduler 登録したジョブを一定間隔で実行する
+// 各ジョブは起動直後に1回実行し、その後は Interval ごとに実行する。
+// 同じジョブが重なって実行されることはなく、失敗はログに出力して次回に再実行する。
+type Scheduler struct {
+	jobs []Job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{stop: make(chan struct{})}
+}
+
+// Every ジョブを登録する（Start より前に呼ぶこと）
+func (s *Scheduler) Every(name string, interval time.Duration, run func() error) {
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start 登録済みのジョブをそれぞれのゴルーチンで実行する
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+// Stop 実行中のジョブの完了を待ってから停止する
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(job)
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Scheduler) run(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("ジョブ %s が異常終了しました: %v", job.Name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(); err != nil {
+		log.Printf("ジョブ %s に失敗しました: %v", job.Name, err)
+		return
+	}
+	log.Printf("ジョブ %s が完了しました（%s）", job.Name, time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/infrastructure/cloudinary"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// CloudinaryStorage Cloudinaryに画像を保存する
+type CloudinaryStorage struct {
+	service *cloudinary.CloudinaryService
+}
+
+func NewCloudinaryStorage(service *cloudinary.CloudinaryService) interfaces.ImageStorage {
+	return &CloudinaryStorage{service: service}
+}
+
+func (s *CloudinaryStorage) Put(ctx context.Context, file io.Reader, folder string, name string) (*interfaces.StoredImage, error) {
+	result, err := s.service.UploadImage(ctx, file, folder, name)
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.StoredImage{
+		Key:    result.PublicID,
+		URL:    result.SecureURL,
+		Width:  result.Width,
+		Height: result.Height,
+		Format: result.Format,
+		Bytes:  result.Bytes,
+	}, nil
+}
+
+func (s *CloudinaryStorage) Delete(ctx context.Context, key string) error {
+	return s.service.DeleteImage(ctx, key)
+}
+
+func (s *CloudinaryStorage) URL(key string) (string, error) {
+	return s.service.ImageURL(key)
+}
+
+func (s *CloudinaryStorage) List(ctx context.Context, prefix string) ([]*interfaces.StoredAsset, error) {
+	assets, err := s.service.ListImages(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]*interfaces.StoredAsset, len(assets))
+	for i, asset := range assets {
+		stored[i] = &interfaces.StoredAsset{
+			Key:       asset.PublicID,
+			Bytes:     asset.Bytes,
+			CreatedAt: asset.CreatedAt,
+		}
+	}
+	return stored, nil
+}
+
+func (s *CloudinaryStorage) Provider() string {
+	return entity.StorageProviderCloudinary
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/infrastructure/imaging"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// LocalStorage ローカルディスクに画像を保存する（開発・テスト用）
+// 保存した画像は baseURL 配下で静的配信されることを前提とする。
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir string, baseURL string) interfaces.ImageStorage {
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *LocalStorage) Put(ctx context.Context, file io.Reader, folder string, name string) (*interfaces.StoredImage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("画像の読み込みに失敗しました: %w", err)
+	}
+
+	info, err := imaging.Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := cleanKey(path.Join(folder, name+"."+info.Format))
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return nil, fmt.Errorf("アップロードディレクトリの作成に失敗しました: %w", err)
+	}
+
+	// 書き込み途中のファイルが配信されないよう、一時ファイルに書いてから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("画像の保存に失敗しました: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("画像の保存に失敗しました: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("画像の保存に失敗しました: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return nil, fmt.Errorf("画像の保存に失敗しました: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return nil, fmt.Errorf("画像の保存に失敗しました: %w", err)
+	}
+
+	url, err := s.URL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.StoredImage{
+		Key:    key,
+		URL:    url,
+		Width:  info.Width,
+		Height: info.Height,
+		Format: info.Format,
+		Bytes:  len(data),
+	}, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("画像の削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+// List prefix をフォルダとみなし、その配下の画像を返す
+// 書き込み途中の一時ファイルは含めない。作成日時にはファイルの更新日時を使う。
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]*interfaces.StoredAsset, error) {
+	prefix, err := cleanKey(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []*interfaces.StoredAsset
+	root := filepath.Join(s.dir, filepath.FromSlash(prefix))
+	err = filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		assets = append(assets, &interfaces.StoredAsset{
+			Key:       filepath.ToSlash(rel),
+			Bytes:     int(info.Size()),
+			CreatedAt: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("画像一覧の取得に失敗しました: %w", err)
+	}
+	return assets, nil
+}
+
+func (s *LocalStorage) Provider() string {
+	return entity.StorageProviderLocal
+}
+
+// cleanKey 保存先ディレクトリの外を指すキーを拒否する
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key {
+		return "", fmt.Errorf("無効な画像キーです: %s", key)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// GenerateImageName レビュー画像の一意な名前を生成
+func GenerateImageName(reviewID uint, timestamp int64, index int) string {
+	return fmt.Sprintf("review_%d_%d_%d", reviewID, timestamp, index)
+}
+
+// GenerateFolderPath フォルダパスを生成
+func GenerateFolderPath() string {
+	return fmt.Sprintf("%s/%d", interfaces.ImageFolderRoot, time.Now().Year())
+}
@@ -1,24 +1,43 @@
 package interactor
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"sidemenulab-backend/internal/domain/entity"
 	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	accessTokenTTL  = 24 * time.Hour
+	refreshTokenTTL = 7 * 24 * time.Hour
+	// streamTicketTTL チケットは接続時にだけ検証するため、接続を始めるのに足りる長さにする
+	streamTicketTTL = time.Minute
+)
+
 type AuthInteractor struct {
-	userRepo repository.UserRepository
-	jwtSecret string
+	userRepo                 repository.UserRepository
+	refreshTokenRepo         repository.RefreshTokenRepository
+	tokenRevocationRepo      repository.TokenRevocationRepository
+	emailVerificationUseCase interfaces.EmailVerificationUseCase
+	jwtSecret                string
 }
 
-func NewAuthInteractor(userRepo repository.UserRepository, jwtSecret string) *AuthInteractor {
+func NewAuthInteractor(userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, tokenRevocationRepo repository.TokenRevocationRepository, emailVerificationUseCase interfaces.EmailVerificationUseCase, jwtSecret string) *AuthInteractor {
 	return &AuthInteractor{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:                 userRepo,
+		refreshTokenRepo:         refreshTokenRepo,
+		tokenRevocationRepo:      tokenRevocationRepo,
+		emailVerificationUseCase: emailVerificationUseCase,
+		jwtSecret:                jwtSecret,
 	}
 }
 
@@ -29,10 +48,17 @@ func (a *AuthInteractor) SignUp(req *entity.SignUpRequest) (*entity.AuthResponse
 		return nil, errors.New("このメールアドレスは既に使用されています")
 	}
 
+	handle, err := a.chooseHandle(req.Handle, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
 	// 新しいユーザーを作成
 	user := &entity.User{
-		Email: req.Email,
-		Name:  req.Name,
+		Email:  req.Email,
+		Handle: handle,
+		Name:   req.Name,
+		Role:   entity.RoleUser,
 	}
 
 	// パスワードをハッシュ化
@@ -45,8 +71,13 @@ func (a *AuthInteractor) SignUp(req *entity.SignUpRequest) (*entity.AuthResponse
 		return nil, err
 	}
 
+	// 確認メールの送信に失敗しても登録自体は完了させる（再送エンドポイントで再試行できる）
+	if err := a.emailVerificationUseCase.SendVerificationEmail(user); err != nil {
+		log.Printf("確認メールの送信に失敗しました - UserID: %d: %v", user.ID, err)
+	}
+
 	// JWTトークンを生成
-	token, err := a.generateToken(user)
+	token, err := a.generateToken(user, "")
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +88,32 @@ func (a *AuthInteractor) SignUp(req *entity.SignUpRequest) (*entity.AuthResponse
 	}, nil
 }
 
+// chooseHandle 指定されたハンドルを検証する。省略された場合はメールアドレスから未使用のハンドルを割り当てる
+func (a *AuthInteractor) chooseHandle(requested string, email string) (string, error) {
+	if requested != "" {
+		handle := entity.NormalizeHandle(requested)
+		if err := entity.ValidateHandle(handle); err != nil {
+			return "", err
+		}
+		if existing, err := a.userRepo.GetByHandle(handle); err == nil && existing != nil {
+			return "", fmt.Errorf("%w: このハンドルは既に使用されています", entity.ErrConflict)
+		}
+		return handle, nil
+	}
+
+	base := entity.HandleBaseFromEmail(email)
+	for n := 1; n <= 20; n++ {
+		handle := base
+		if n > 1 {
+			handle = fmt.Sprintf("%s_%d", base, n)
+		}
+		if _, err := a.userRepo.GetByHandle(handle); err != nil {
+			return handle, nil
+		}
+	}
+	return "", errors.New("ハンドルを割り当てられませんでした。ハンドルを指定して再度お試しください")
+}
+
 func (a *AuthInteractor) SignIn(req *entity.SignInRequest) (*entity.AuthResponse, error) {
 	// ユーザーをメールアドレスで検索
 	user, err := a.userRepo.GetByEmail(req.Email)
@@ -70,7 +127,56 @@ func (a *AuthInteractor) SignIn(req *entity.SignInRequest) (*entity.AuthResponse
 	}
 
 	// JWTトークンを生成
-	token, err := a.generateToken(user)
+	token, err := a.generateToken(user, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AuthResponse{
+		User:  user,
+		Token: token,
+	}, nil
+}
+
+// Refresh リフレッシュトークンを検証し、新しいトークンペアを発行する
+// 使用済みのリフレッシュトークンが再度提示された場合は、同じファミリーのトークンをすべて失効させる
+func (a *AuthInteractor) Refresh(req *entity.RefreshTokenRequest) (*entity.AuthResponse, error) {
+	errInvalid := errors.New("リフレッシュトークンが無効です")
+
+	claims, err := a.parseToken(req.RefreshToken, entity.TokenTypeRefresh)
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	stored, err := a.refreshTokenRepo.GetByTokenHash(hashToken(req.RefreshToken))
+	if err != nil || stored.UserID != claims.UserID {
+		return nil, errInvalid
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil || now.After(stored.ExpiresAt) {
+		return nil, errInvalid
+	}
+
+	// 使用済みトークンの再利用は漏洩とみなしてファミリーごと失効させる
+	if stored.RotatedAt != nil {
+		return nil, a.revokeFamilyOnReuse(stored)
+	}
+
+	rotated, err := a.refreshTokenRepo.MarkRotated(stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, a.revokeFamilyOnReuse(stored)
+	}
+
+	user, err := a.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	token, err := a.generateToken(user, stored.FamilyID)
 	if err != nil {
 		return nil, err
 	}
@@ -81,14 +187,188 @@ func (a *AuthInteractor) SignIn(req *entity.SignInRequest) (*entity.AuthResponse
 	}, nil
 }
 
-func (a *AuthInteractor) generateToken(user *entity.User) (*entity.AuthToken, error) {
-	// アクセストークンの生成
+// SignOut 現在のアクセストークンを失効させる
+// リフレッシュトークンが指定された場合は、そのファミリーも失効させる
+func (a *AuthInteractor) SignOut(userID uint, jti string, expiresAt time.Time, req *entity.SignOutRequest) error {
+	if err := a.tokenRevocationRepo.RevokeToken(&entity.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return fmt.Errorf("サインアウトに失敗しました: %w", err)
+	}
+
+	if req == nil || req.RefreshToken == "" {
+		return nil
+	}
+
+	// 他人のリフレッシュトークンや無効なトークンは無視する
+	stored, err := a.refreshTokenRepo.GetByTokenHash(hashToken(req.RefreshToken))
+	if err != nil || stored.UserID != userID {
+		return nil
+	}
+	if err := a.refreshTokenRepo.RevokeFamily(stored.FamilyID, time.Now()); err != nil {
+		return fmt.Errorf("サインアウトに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// SignOutAll ユーザーのすべての端末からサインアウトする
+func (a *AuthInteractor) SignOutAll(userID uint) error {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return errors.New("ユーザーが見つかりません")
+	}
+	if err := revokeAllUserTokens(a.refreshTokenRepo, a.tokenRevocationRepo, user); err != nil {
+		return fmt.Errorf("全端末からのサインアウトに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// IssueStreamTicket リアルタイム配信用のチケットを発行する
+// アクセストークンと違い、URL（アクセスログ）に残っても他の API には使えず、すぐに期限が切れる。
+func (a *AuthInteractor) IssueStreamTicket(userID uint) (*entity.StreamTicket, error) {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("ユーザーが見つかりません")
+	}
+
+	jti, err := generateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(streamTicketTTL)
+	ticket, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.JWTClaims{
+		UserID:          user.ID,
+		Email:           user.Email,
+		Role:            user.Role,
+		TokenType:       entity.TokenTypeStream,
+		TokenGeneration: user.TokenGeneration,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}).SignedString([]byte(a.jwtSecret))
+	if err != nil {
+		return nil, err
+	}
+	return &entity.StreamTicket{Ticket: ticket, ExpiresAt: expiresAt}, nil
+}
+
+// ChangePassword パスワードを変更し、既存のトークンをすべて失効させた上で新しいトークンを発行する
+func (a *AuthInteractor) ChangePassword(userID uint, req *entity.ChangePasswordRequest) (*entity.AuthResponse, error) {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("ユーザーが見つかりません")
+	}
+
+	if !user.CheckPassword(req.CurrentPassword) {
+		return nil, errors.New("現在のパスワードが正しくありません")
+	}
+
+	if err := user.HashPassword(req.NewPassword); err != nil {
+		return nil, err
+	}
+	if err := a.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("パスワードの更新に失敗しました: %w", err)
+	}
+
+	if err := revokeAllUserTokens(a.refreshTokenRepo, a.tokenRevocationRepo, user); err != nil {
+		return nil, fmt.Errorf("既存トークンの失効に失敗しました: %w", err)
+	}
+
+	token, err := a.generateToken(user, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AuthResponse{
+		User:  user,
+		Token: token,
+	}, nil
+}
+
+// IsTokenRevoked アクセストークンが失効済みかどうかを判定する
+// generation はトークンの発行時の一括失効の世代。現在の世代より小さければ一括失効済み。
+func (a *AuthInteractor) IsTokenRevoked(jti string, userID uint, generation uint64) (bool, error) {
+	revoked, err := a.tokenRevocationRepo.IsTokenRevoked(jti)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	current, err := a.tokenRevocationRepo.GetUserTokenGeneration(userID)
+	if err != nil {
+		return false, err
+	}
+	return generation < current, nil
+}
+
+// PurgeExpiredTokens 有効期限の切れたリフレッシュトークンと失効リストの行を削除する
+// 期限切れのトークンは署名の検証で拒否されるため、失効の記録を残しておく必要はない。
+func (a *AuthInteractor) PurgeExpiredTokens() error {
+	now := time.Now()
+	refreshTokens, err := a.refreshTokenRepo.DeleteExpired(now)
+	if err != nil {
+		return fmt.Errorf("期限切れのリフレッシュトークンの削除に失敗しました: %w", err)
+	}
+	revokedTokens, err := a.tokenRevocationRepo.DeleteExpired(now)
+	if err != nil {
+		return fmt.Errorf("期限切れの失効リストの削除に失敗しました: %w", err)
+	}
+	if refreshTokens > 0 || revokedTokens > 0 {
+		log.Printf("期限切れのトークンを削除しました - リフレッシュトークン: %d件, 失効リスト: %d件", refreshTokens, revokedTokens)
+	}
+	return nil
+}
+
+func (a *AuthInteractor) revokeFamilyOnReuse(stored *entity.RefreshToken) error {
+	log.Printf("リフレッシュトークンの再利用を検出しました - UserID: %d, FamilyID: %s", stored.UserID, stored.FamilyID)
+	if err := a.refreshTokenRepo.RevokeFamily(stored.FamilyID, time.Now()); err != nil {
+		return err
+	}
+	return errors.New("リフレッシュトークンの再利用が検出されたため、セッションを無効化しました")
+}
+
+// parseToken 署名と有効期限、トークン種別を検証してクレームを返す
+func (a *AuthInteractor) parseToken(tokenString string, tokenType string) (*entity.JWTClaims, error) {
+	claims := &entity.JWTClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(a.jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errors.New("トークンが無効です")
+	}
+	if claims.TokenType != tokenType {
+		return nil, errors.New("トークンの種別が正しくありません")
+	}
+	return claims, nil
+}
+
+// generateToken アクセストークンとリフレッシュトークンを生成する
+// familyID が空の場合は新しいリフレッシュトークンファミリーを開始する
+func (a *AuthInteractor) generateToken(user *entity.User, familyID string) (*entity.AuthToken, error) {
+	now := time.Now()
+
+	// アクセストークンの生成（jtiは個別の失効に使用）
+	accessJTI, err := generateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:          user.ID,
+		Email:           user.Email,
+		Role:            user.Role,
+		TokenType:       entity.TokenTypeAccess,
+		TokenGeneration: user.TokenGeneration,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        accessJTI,
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	})
 
@@ -97,13 +377,28 @@ func (a *AuthInteractor) generateToken(user *entity.User) (*entity.AuthToken, er
 		return nil, err
 	}
 
-	// リフレッシュトークンの生成
+	if familyID == "" {
+		familyID, err = generateRandomToken(16)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// リフレッシュトークンの生成（jtiで同一秒内の発行でも一意にする）
+	refreshJTI, err := generateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	refreshExpiresAt := now.Add(refreshTokenTTL)
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		TokenType: entity.TokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        refreshJTI,
+			ExpiresAt: jwt.NewNumericDate(refreshExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	})
 
@@ -112,10 +407,50 @@ func (a *AuthInteractor) generateToken(user *entity.User) (*entity.AuthToken, er
 		return nil, err
 	}
 
+	// リフレッシュトークンはハッシュのみ保存
+	if err := a.refreshTokenRepo.Create(&entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshTokenString),
+		FamilyID:  familyID,
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
 	return &entity.AuthToken{
 		AccessToken:  accessTokenString,
 		RefreshToken: refreshTokenString,
-		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		ExpiresAt:    now.Add(accessTokenTTL),
 		TokenType:    "Bearer",
 	}, nil
 }
+
+// revokeAllUserTokens ユーザーのリフレッシュトークンと発行済みアクセストークンをすべて失効させる
+// アクセストークンは一括失効の世代を進めて無効にする（発行時刻の比較は秒単位のため、同じ秒に発行したトークンを区別できない）。
+// user の世代も新しい値に更新するため、この後に発行するトークンは有効になる。
+func revokeAllUserTokens(refreshTokenRepo repository.RefreshTokenRepository, tokenRevocationRepo repository.TokenRevocationRepository, user *entity.User) error {
+	if err := refreshTokenRepo.RevokeAllByUserID(user.ID, time.Now()); err != nil {
+		return err
+	}
+	generation, err := tokenRevocationRepo.RevokeAllForUser(user.ID)
+	if err != nil {
+		return err
+	}
+	user.TokenGeneration = generation
+	return nil
+}
+
+// generateRandomToken 暗号論的乱数から16進文字列を生成
+func generateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken トークンを保存用にハッシュ化
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,79 @@
+package interactor
+
+import (
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// リソース名（ForbiddenError / NotFoundError のメッセージに使用）
+const (
+	resourceReview   = "レビュー"
+	resourceImage    = "レビュー画像"
+	resourceComment  = "コメント"
+	resourceUser     = "ユーザー"
+	resourceStore    = "店舗"
+	resourceSideMenu = "サイドメニュー"
+	// resourceNotification 通知は受信者本人のみが操作できる
+	resourceNotification = "通知"
+)
+
+type AuthorizationService struct {
+	reviewRepo        repository.ReviewRepository
+	reviewCommentRepo repository.ReviewCommentRepository
+}
+
+func NewAuthorizationService(reviewRepo repository.ReviewRepository, reviewCommentRepo repository.ReviewCommentRepository) interfaces.AuthorizationService {
+	return &AuthorizationService{
+		reviewRepo:        reviewRepo,
+		reviewCommentRepo: reviewCommentRepo,
+	}
+}
+
+// AuthorizeReview レビューの所有者、またはコンテンツ管理権限を持つユーザーのみ許可する
+func (s *AuthorizationService) AuthorizeReview(reviewID uint, actor *entity.Actor, action string) (*entity.SideMenuReview, error) {
+	review, err := s.reviewRepo.GetReviewByID(reviewID)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceReview}
+	}
+
+	if !actor.CanModify(review.UserID) {
+		return nil, forbidden(resourceReview, review.ID, action, actor)
+	}
+	return review, nil
+}
+
+// AuthorizeReviewImage 画像が属するレビューの所有者、またはコンテンツ管理権限を持つユーザーのみ許可する
+func (s *AuthorizationService) AuthorizeReviewImage(imageID uint, actor *entity.Actor, action string) (*entity.SideMenuReviewImage, error) {
+	image, err := s.reviewRepo.GetReviewImageByID(imageID)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceImage}
+	}
+
+	if !actor.CanModify(image.Review.UserID) {
+		return nil, forbidden(resourceImage, image.ID, action, actor)
+	}
+	return image, nil
+}
+
+// AuthorizeReviewComment コメントの投稿者、またはコンテンツ管理権限を持つユーザーのみ許可する
+func (s *AuthorizationService) AuthorizeReviewComment(commentID uint, actor *entity.Actor, action string) (*entity.ReviewComment, error) {
+	comment, err := s.reviewCommentRepo.GetReviewCommentByID(commentID)
+	if err != nil || comment.IsDeleted {
+		// 削除済みのプレースホルダーは編集・削除の対象にしない
+		return nil, &entity.NotFoundError{Resource: resourceComment}
+	}
+
+	if !actor.CanModify(comment.UserID) {
+		return nil, forbidden(resourceComment, comment.ID, action, actor)
+	}
+	return comment, nil
+}
+
+func forbidden(resource string, resourceID uint, action string, actor *entity.Actor) error {
+	err := &entity.ForbiddenError{Resource: resource, ResourceID: resourceID, Action: action}
+	if actor != nil {
+		err.UserID = actor.UserID
+	}
+	return err
+}
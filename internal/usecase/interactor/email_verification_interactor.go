@@ -0,0 +1,164 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+const (
+	emailVerificationTokenTTL = 24 * time.Hour
+	// 再送の間隔と、24時間あたりの送信上限
+	emailVerificationResendInterval = time.Minute
+	emailVerificationDailyLimit     = 5
+)
+
+type EmailVerificationInteractor struct {
+	userRepo              repository.UserRepository
+	verificationTokenRepo repository.EmailVerificationTokenRepository
+	mailer                interfaces.Mailer
+	verifyURL             string
+}
+
+func NewEmailVerificationInteractor(userRepo repository.UserRepository, verificationTokenRepo repository.EmailVerificationTokenRepository, mailer interfaces.Mailer, verifyURL string) interfaces.EmailVerificationUseCase {
+	return &EmailVerificationInteractor{
+		userRepo:              userRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		mailer:                mailer,
+		verifyURL:             verifyURL,
+	}
+}
+
+// SendVerificationEmail 確認用トークンを発行してメールを送信する
+func (i *EmailVerificationInteractor) SendVerificationEmail(user *entity.User) error {
+	now := time.Now()
+	if err := i.verificationTokenRepo.InvalidateByUserID(user.ID, now); err != nil {
+		return fmt.Errorf("確認メールの送信に失敗しました: %w", err)
+	}
+
+	token, err := generateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	if err := i.verificationTokenRepo.Create(&entity.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(emailVerificationTokenTTL),
+	}); err != nil {
+		return fmt.Errorf("確認メールの送信に失敗しました: %w", err)
+	}
+
+	msg := &entity.MailMessage{
+		To:      user.Email,
+		Subject: "【サイドメニュー研究所】メールアドレスの確認",
+		Body: fmt.Sprintf("%s 様\n\nご登録ありがとうございます。以下のリンクからメールアドレスの確認を完了してください（有効期限: %d時間）。\n\n%s\n\nお心当たりのない場合は、このメールを破棄してください。\n",
+			user.Name, int(emailVerificationTokenTTL.Hours()), buildTokenURL(i.verifyURL, token)),
+	}
+	if err := i.mailer.Send(context.Background(), msg); err != nil {
+		return fmt.Errorf("確認メールの送信に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail 確認トークンを検証してメールアドレスを確認済みにする
+func (i *EmailVerificationInteractor) VerifyEmail(req *entity.VerifyEmailRequest) error {
+	errInvalid := errors.New("確認用のトークンが無効か、有効期限が切れています")
+
+	stored, err := i.verificationTokenRepo.GetByTokenHash(hashToken(req.Token))
+	if err != nil {
+		return errInvalid
+	}
+
+	now := time.Now()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return errInvalid
+	}
+
+	used, err := i.verificationTokenRepo.MarkUsed(stored.ID, now)
+	if err != nil {
+		return fmt.Errorf("メールアドレスの確認に失敗しました: %w", err)
+	}
+	if !used {
+		return errInvalid
+	}
+
+	user, err := i.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return errInvalid
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	user.EmailVerifiedAt = &now
+	if err := i.userRepo.Update(user); err != nil {
+		return fmt.Errorf("メールアドレスの確認に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ResendVerificationEmail 確認メールを再送する
+// 短時間の連続送信と、24時間あたりの送信回数を制限する
+func (i *EmailVerificationInteractor) ResendVerificationEmail(userID uint) error {
+	user, err := i.userRepo.GetByID(userID)
+	if err != nil {
+		return errors.New("ユーザーが見つかりません")
+	}
+	if user.IsEmailVerified() {
+		return errors.New("メールアドレスは既に確認済みです")
+	}
+
+	now := time.Now()
+	latest, err := i.verificationTokenRepo.GetLatestByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("確認メールの再送に失敗しました: %w", err)
+	}
+	if latest != nil && now.Sub(latest.CreatedAt) < emailVerificationResendInterval {
+		return entity.ErrTooManyRequests
+	}
+
+	count, err := i.verificationTokenRepo.CountCreatedSince(userID, now.Add(-24*time.Hour))
+	if err != nil {
+		return fmt.Errorf("確認メールの再送に失敗しました: %w", err)
+	}
+	if count >= emailVerificationDailyLimit {
+		return entity.ErrTooManyRequests
+	}
+
+	return i.SendVerificationEmail(user)
+}
+
+// emailVerificationPolicy 設定に応じてメールアドレス未確認ユーザーの投稿を制限する
+type emailVerificationPolicy struct {
+	userRepo repository.UserRepository
+	required bool
+}
+
+// NewEmailVerificationPolicy required が false の場合は常に投稿を許可する
+func NewEmailVerificationPolicy(userRepo repository.UserRepository, required bool) interfaces.EmailVerificationPolicy {
+	return &emailVerificationPolicy{
+		userRepo: userRepo,
+		required: required,
+	}
+}
+
+func (p *emailVerificationPolicy) CheckCanPost(userID uint) error {
+	if !p.required {
+		return nil
+	}
+
+	user, err := p.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("ユーザーの取得に失敗しました: %w", err)
+	}
+	if !user.IsEmailVerified() {
+		return entity.ErrEmailNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// imageDeletionBatchSize 1回の実行で処理するジョブの最大件数
+const imageDeletionBatchSize = 50
+
+// imageDeletionTimeout 1件あたりの削除のタイムアウト
+const imageDeletionTimeout = 30 * time.Second
+
+type ImageDeletionInteractor struct {
+	imageDeletionJobRepo repository.ImageDeletionJobRepository
+	reviewRepo           repository.ReviewRepository
+	imageStorage         interfaces.ImageStorage
+}
+
+func NewImageDeletionInteractor(imageDeletionJobRepo repository.ImageDeletionJobRepository, reviewRepo repository.ReviewRepository, imageStorage interfaces.ImageStorage) interfaces.ImageDeletionUseCase {
+	return &ImageDeletionInteractor{
+		imageDeletionJobRepo: imageDeletionJobRepo,
+		reviewRepo:           reviewRepo,
+		imageStorage:         imageStorage,
+	}
+}
+
+// ProcessImageDeletions 現在の保存先のジョブのみ処理する（他の保存先のジョブはその保存先で動くまで残す）
+// 他の画像の行がまだ同じキーを参照している場合は、保存先からは削除せずにジョブだけを取り除く。
+func (i *ImageDeletionInteractor) ProcessImageDeletions() error {
+	provider := i.imageStorage.Provider()
+	jobs, err := i.imageDeletionJobRepo.GetDueImageDeletionJobs(provider, time.Now(), imageDeletionBatchSize)
+	if err != nil {
+		return fmt.Errorf("画像削除ジョブの取得に失敗しました: %w", err)
+	}
+
+	keys := make([]string, len(jobs))
+	for n, job := range jobs {
+		keys[n] = job.StorageKey
+	}
+	referenced, err := i.reviewRepo.GetReferencedImageKeys(provider, keys)
+	if err != nil {
+		return fmt.Errorf("画像の参照の確認に失敗しました: %w", err)
+	}
+
+	failed := 0
+	for _, job := range jobs {
+		if referenced[job.StorageKey] {
+			log.Printf("画像 %s は他の画像から参照されているため、保存先からは削除しません", job.StorageKey)
+			if err := i.imageDeletionJobRepo.DeleteImageDeletionJob(job.ID); err != nil {
+				return fmt.Errorf("画像削除ジョブの削除に失敗しました: %w", err)
+			}
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), imageDeletionTimeout)
+		err := i.imageStorage.Delete(ctx, job.StorageKey)
+		cancel()
+
+		if err != nil {
+			failed++
+			job.Fail(err, time.Now())
+			log.Printf("画像 %s の削除に失敗しました（%d回目）: %v", job.StorageKey, job.Attempts, err)
+			if err := i.imageDeletionJobRepo.UpdateImageDeletionJob(job); err != nil {
+				return fmt.Errorf("画像削除ジョブの更新に失敗しました: %w", err)
+			}
+			continue
+		}
+
+		if err := i.imageDeletionJobRepo.DeleteImageDeletionJob(job.ID); err != nil {
+			return fmt.Errorf("画像削除ジョブの削除に失敗しました: %w", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d件中%d件の画像の削除に失敗しました", len(jobs), failed)
+	}
+	return nil
+}
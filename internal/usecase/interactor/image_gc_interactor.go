@@ -0,0 +1,134 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// imageGCListTimeout 保存先の画像一覧の取得のタイムアウト
+const imageGCListTimeout = 5 * time.Minute
+
+type ImageGCInteractor struct {
+	reviewRepo           repository.ReviewRepository
+	imageDeletionJobRepo repository.ImageDeletionJobRepository
+	imageStorage         interfaces.ImageStorage
+}
+
+func NewImageGCInteractor(reviewRepo repository.ReviewRepository, imageDeletionJobRepo repository.ImageDeletionJobRepository, imageStorage interfaces.ImageStorage) interfaces.ImageGCUseCase {
+	return &ImageGCInteractor{
+		reviewRepo:           reviewRepo,
+		imageDeletionJobRepo: imageDeletionJobRepo,
+		imageStorage:         imageStorage,
+	}
+}
+
+// CollectOrphanedImages interfaces.ImageFolderRoot 配下の画像を画像の行と突き合わせる
+// 削除ジョブに登録済みの画像は削除ジョブに任せ、ここでは削除しない。
+func (i *ImageGCInteractor) CollectOrphanedImages(options entity.ImageGCOptions) (*entity.ImageGCReport, error) {
+	report := &entity.ImageGCReport{
+		Provider: i.imageStorage.Provider(),
+		Prefix:   interfaces.ImageFolderRoot,
+		DryRun:   options.DryRun,
+		Orphans:  []*entity.OrphanedImage{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageGCListTimeout)
+	assets, err := i.imageStorage.List(ctx, interfaces.ImageFolderRoot+"/")
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("保存先の画像一覧の取得に失敗しました: %w", err)
+	}
+	report.Scanned = len(assets)
+
+	cutoff := time.Now().Add(-options.GracePeriod)
+	var candidates []*interfaces.StoredAsset
+	var keys []string
+	for _, asset := range assets {
+		if asset.CreatedAt.After(cutoff) {
+			report.Recent++
+			continue
+		}
+		candidates = append(candidates, asset)
+		keys = append(keys, asset.Key)
+	}
+
+	referenced, err := i.reviewRepo.GetReferencedImageKeys(report.Provider, keys)
+	if err != nil {
+		return nil, fmt.Errorf("画像の参照の確認に失敗しました: %w", err)
+	}
+	unkeyedURLs, err := i.reviewRepo.GetUnkeyedReviewImageURLs()
+	if err != nil {
+		return nil, fmt.Errorf("画像の参照の確認に失敗しました: %w", err)
+	}
+	queued, err := i.imageDeletionJobRepo.GetQueuedImageKeys(report.Provider, keys)
+	if err != nil {
+		return nil, fmt.Errorf("画像削除ジョブの確認に失敗しました: %w", err)
+	}
+
+	for _, asset := range candidates {
+		switch {
+		case referenced[asset.Key] || referencedByURL(unkeyedURLs, asset.Key):
+			report.Referenced++
+		case queued[asset.Key]:
+			report.Queued++
+		default:
+			report.Orphans = append(report.Orphans, &entity.OrphanedImage{
+				Key:       asset.Key,
+				Bytes:     asset.Bytes,
+				CreatedAt: asset.CreatedAt,
+			})
+		}
+	}
+
+	if !options.DryRun {
+		for _, orphan := range report.Orphans {
+			ctx, cancel := context.WithTimeout(context.Background(), imageDeletionTimeout)
+			err := i.imageStorage.Delete(ctx, orphan.Key)
+			cancel()
+
+			if err != nil {
+				orphan.Error = err.Error()
+				report.Failed++
+				continue
+			}
+			orphan.Deleted = true
+			report.Deleted++
+		}
+	}
+
+	for _, orphan := range report.Orphans {
+		switch {
+		case options.DryRun:
+			log.Printf("参照されていない画像（dry-run のため削除しません）: %s", orphan.Key)
+		case orphan.Deleted:
+			log.Printf("参照されていない画像を削除しました: %s", orphan.Key)
+		default:
+			log.Printf("参照されていない画像 %s の削除に失敗しました: %s", orphan.Key, orphan.Error)
+		}
+	}
+	log.Printf("画像の回収結果 - 保存先: %s, 走査: %d, 猶予期間内: %d, 参照あり: %d, 削除待ち: %d, 未参照: %d, 削除: %d, 失敗: %d",
+		report.Provider, report.Scanned, report.Recent, report.Referenced, report.Queued, len(report.Orphans), report.Deleted, report.Failed)
+
+	if report.Failed > 0 {
+		return report, fmt.Errorf("%d件中%d件の画像の削除に失敗しました", len(report.Orphans), report.Failed)
+	}
+	return report, nil
+}
+
+// referencedByURL キーを記録していない画像のURLが key の画像を指しているか
+// CloudinaryのURLは .../upload/v123/<PublicID>.<拡張子>、ローカルのURLは .../<相対パス> の形になる。
+func referencedByURL(urls []string, key string) bool {
+	for _, url := range urls {
+		if strings.HasSuffix(url, "/"+key) || strings.Contains(url, "/"+key+".") {
+			return true
+		}
+	}
+	return false
+}
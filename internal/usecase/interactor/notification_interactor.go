@@ -0,0 +1,78 @@
+package interactor
+
+import (
+	"fmt"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+type NotificationInteractor struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationInteractor(notificationRepo repository.NotificationRepository) interfaces.NotificationUseCase {
+	return &NotificationInteractor{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (i *NotificationInteractor) ListNotifications(userID uint, unreadOnly bool, page entity.PageRequest) ([]*entity.Notification, *entity.PageInfo, error) {
+	notifications, pageInfo, err := i.notificationRepo.GetNotificationsByUserID(userID, unreadOnly, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("通知一覧の取得に失敗しました: %w", err)
+	}
+	return notifications, pageInfo, nil
+}
+
+func (i *NotificationInteractor) CountUnread(userID uint) (int64, error) {
+	count, err := i.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return 0, fmt.Errorf("未読の通知件数の取得に失敗しました: %w", err)
+	}
+	return count, nil
+}
+
+func (i *NotificationInteractor) MarkRead(userID uint, id uint) error {
+	found, err := i.notificationRepo.MarkRead(userID, id)
+	if err != nil {
+		return fmt.Errorf("通知の既読化に失敗しました: %w", err)
+	}
+	if !found {
+		return &entity.NotFoundError{Resource: resourceNotification}
+	}
+	return nil
+}
+
+func (i *NotificationInteractor) MarkAllRead(userID uint) (int64, error) {
+	updated, err := i.notificationRepo.MarkAllRead(userID)
+	if err != nil {
+		return 0, fmt.Errorf("通知の既読化に失敗しました: %w", err)
+	}
+	return updated, nil
+}
+
+func (i *NotificationInteractor) GetPreferences(userID uint) (entity.NotificationPreferences, error) {
+	saved, err := i.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("通知の受信設定の取得に失敗しました: %w", err)
+	}
+	prefs := entity.DefaultNotificationPreferences()
+	for t, enabled := range saved {
+		if _, ok := prefs[t]; ok {
+			prefs[t] = enabled
+		}
+	}
+	return prefs, nil
+}
+
+func (i *NotificationInteractor) UpdatePreferences(userID uint, prefs entity.NotificationPreferences) (entity.NotificationPreferences, error) {
+	if err := prefs.Validate(); err != nil {
+		return nil, err
+	}
+	if err := i.notificationRepo.SavePreferences(userID, prefs); err != nil {
+		return nil, fmt.Errorf("通知の受信設定の更新に失敗しました: %w", err)
+	}
+	return i.GetPreferences(userID)
+}
@@ -0,0 +1,96 @@
+package interactor
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// notificationMessages 種類ごとの通知文（%s は操作したユーザーの名前）
+var notificationMessages = map[entity.NotificationType]string{
+	entity.NotificationReviewLiked:     "%sさんがあなたのレビューにいいねしました",
+	entity.NotificationReviewCommented: "%sさんがあなたのレビューにコメントしました",
+	entity.NotificationCommentReplied:  "%sさんがあなたのコメントに返信しました",
+	entity.NotificationMentioned:       "%sさんがコメントであなたをメンションしました",
+}
+
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	eventPublisher   interfaces.EventPublisher
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository, userRepo repository.UserRepository, eventPublisher interfaces.EventPublisher) interfaces.NotificationPublisher {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		eventPublisher:   eventPublisher,
+	}
+}
+
+// Publish 受信設定を確認して通知を作成する
+// Message が空の場合は、種類と操作したユーザーの名前から通知文を組み立てる。
+func (s *NotificationService) Publish(notification *entity.Notification) (bool, error) {
+	if notification.ActorID != nil && *notification.ActorID == notification.UserID {
+		return false, nil
+	}
+
+	prefs, err := s.notificationRepo.GetPreferences(notification.UserID)
+	if err != nil {
+		return false, fmt.Errorf("通知の受信設定の取得に失敗しました: %w", err)
+	}
+	if enabled, ok := prefs[notification.Type]; ok && !enabled {
+		return false, nil
+	}
+
+	if window := notification.Type.DedupWindow(); window > 0 {
+		exists, err := s.notificationRepo.ExistsSince(notification, time.Now().Add(-window))
+		if err != nil {
+			return false, fmt.Errorf("通知の重複確認に失敗しました: %w", err)
+		}
+		if exists {
+			return false, nil
+		}
+	}
+
+	if notification.Message == "" {
+		name := "ユーザー"
+		if notification.ActorID != nil {
+			if actor, err := s.userRepo.GetByID(*notification.ActorID); err == nil {
+				name = actor.Name
+			}
+		}
+		notification.Message = fmt.Sprintf(notificationMessages[notification.Type], name)
+	}
+
+	if err := s.notificationRepo.CreateNotification(notification); err != nil {
+		return false, fmt.Errorf("通知の作成に失敗しました: %w", err)
+	}
+
+	s.eventPublisher.Publish(entity.UserNotificationTopic(notification.UserID), &entity.Event{Type: entity.EventNotificationCreated, Data: notification})
+	return true, nil
+}
+
+// publishNotification 通知を発行する。通知に失敗しても元の操作は成功として扱うため、エラーはログに残すだけにする
+func publishNotification(publisher interfaces.NotificationPublisher, notification *entity.Notification) bool {
+	published, err := publisher.Publish(notification)
+	if err != nil {
+		log.Printf("通知の発行に失敗しました - UserID: %d, Type: %s: %v", notification.UserID, notification.Type, err)
+		return false
+	}
+	return published
+}
+
+// moderationNotification 他のユーザーによる投稿の編集・削除を投稿者に知らせる通知を組み立てる
+// 対応したモデレーターは通知に含めない。
+func moderationNotification(ownerID uint, message string) *entity.Notification {
+	return &entity.Notification{
+		UserID:  ownerID,
+		Type:    entity.NotificationModeration,
+		Message: message,
+	}
+}
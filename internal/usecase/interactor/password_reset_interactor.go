@@ -0,0 +1,155 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+const (
+	passwordResetTokenTTL = time.Hour
+	// 送信の間隔と、24時間あたりの送信上限（確認メールの再送と同じ）
+	passwordResetResendInterval = time.Minute
+	passwordResetDailyLimit     = 5
+)
+
+type PasswordResetInteractor struct {
+	userRepo            repository.UserRepository
+	resetTokenRepo      repository.PasswordResetTokenRepository
+	refreshTokenRepo    repository.RefreshTokenRepository
+	tokenRevocationRepo repository.TokenRevocationRepository
+	mailer              interfaces.Mailer
+	resetURL            string
+}
+
+func NewPasswordResetInteractor(userRepo repository.UserRepository, resetTokenRepo repository.PasswordResetTokenRepository, refreshTokenRepo repository.RefreshTokenRepository, tokenRevocationRepo repository.TokenRevocationRepository, mailer interfaces.Mailer, resetURL string) interfaces.PasswordResetUseCase {
+	return &PasswordResetInteractor{
+		userRepo:            userRepo,
+		resetTokenRepo:      resetTokenRepo,
+		refreshTokenRepo:    refreshTokenRepo,
+		tokenRevocationRepo: tokenRevocationRepo,
+		mailer:              mailer,
+		resetURL:            resetURL,
+	}
+}
+
+// ForgotPassword パスワードリセット用のメールを送信する
+// メールアドレスの登録有無を推測されないよう、未登録の場合や送信失敗時もエラーを返さない
+// 大量送信と、発行済みのトークンの無効化を繰り返されないよう、短時間の連続送信と24時間あたりの送信回数を制限する（制限した場合もエラーを返さない）
+func (i *PasswordResetInteractor) ForgotPassword(req *entity.ForgotPasswordRequest) error {
+	user, err := i.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	latest, err := i.resetTokenRepo.GetLatestByUserID(user.ID)
+	if err != nil {
+		return fmt.Errorf("パスワードリセットの受付に失敗しました: %w", err)
+	}
+	if latest != nil && now.Sub(latest.CreatedAt) < passwordResetResendInterval {
+		return nil
+	}
+	count, err := i.resetTokenRepo.CountCreatedSince(user.ID, now.Add(-24*time.Hour))
+	if err != nil {
+		return fmt.Errorf("パスワードリセットの受付に失敗しました: %w", err)
+	}
+	if count >= passwordResetDailyLimit {
+		log.Printf("パスワードリセットメールの送信回数が上限に達しました - UserID: %d", user.ID)
+		return nil
+	}
+
+	// 以前に発行したトークンは無効にする
+	if err := i.resetTokenRepo.InvalidateByUserID(user.ID, now); err != nil {
+		return fmt.Errorf("パスワードリセットの受付に失敗しました: %w", err)
+	}
+
+	token, err := generateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	if err := i.resetTokenRepo.Create(&entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(passwordResetTokenTTL),
+	}); err != nil {
+		return fmt.Errorf("パスワードリセットの受付に失敗しました: %w", err)
+	}
+
+	msg := &entity.MailMessage{
+		To:      user.Email,
+		Subject: "【サイドメニュー研究所】パスワード再設定のご案内",
+		Body: fmt.Sprintf("%s 様\n\n以下のリンクからパスワードを再設定してください（有効期限: %d分）。\n\n%s\n\nお心当たりのない場合は、このメールを破棄してください。\n",
+			user.Name, int(passwordResetTokenTTL.Minutes()), buildTokenURL(i.resetURL, token)),
+	}
+	if err := i.mailer.Send(context.Background(), msg); err != nil {
+		log.Printf("パスワードリセットメールの送信に失敗しました - UserID: %d: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// ResetPassword リセットトークンを検証してパスワードを再設定する
+// 再設定後は既存のトークンをすべて失効させる
+func (i *PasswordResetInteractor) ResetPassword(req *entity.ResetPasswordRequest) error {
+	errInvalid := errors.New("パスワード再設定用のトークンが無効か、有効期限が切れています")
+
+	stored, err := i.resetTokenRepo.GetByTokenHash(hashToken(req.Token))
+	if err != nil {
+		return errInvalid
+	}
+
+	now := time.Now()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return errInvalid
+	}
+
+	used, err := i.resetTokenRepo.MarkUsed(stored.ID, now)
+	if err != nil {
+		return fmt.Errorf("パスワードの再設定に失敗しました: %w", err)
+	}
+	if !used {
+		return errInvalid
+	}
+
+	user, err := i.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return errInvalid
+	}
+
+	if err := user.HashPassword(req.NewPassword); err != nil {
+		return err
+	}
+	if err := i.userRepo.Update(user); err != nil {
+		return fmt.Errorf("パスワードの再設定に失敗しました: %w", err)
+	}
+
+	if err := i.resetTokenRepo.InvalidateByUserID(user.ID, now); err != nil {
+		return fmt.Errorf("パスワードの再設定に失敗しました: %w", err)
+	}
+	if err := revokeAllUserTokens(i.refreshTokenRepo, i.tokenRevocationRepo, user); err != nil {
+		return fmt.Errorf("既存トークンの失効に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// buildTokenURL フロントエンドのURLにトークンをクエリパラメータとして付与する
+func buildTokenURL(baseURL string, token string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,137 @@
+package interactor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+// rankingCategories ランキングを計算するカテゴリ（空文字列は全カテゴリ）
+var rankingCategories = []string{
+	"",
+	entity.SideMenuCategoryFries,
+	entity.SideMenuCategoryFriedChicken,
+	entity.SideMenuCategorySalad,
+	entity.SideMenuCategorySoup,
+	entity.SideMenuCategoryDessert,
+	entity.SideMenuCategoryDrink,
+	entity.SideMenuCategoryOther,
+}
+
+// RankingInteractor ランキングを定期的に再計算し、メモリ上にキャッシュして返す
+// リクエストのたびに集計しないよう、読み取りは最後に計算したランキングから行う。
+type RankingInteractor struct {
+	rankingRepo     repository.RankingRepository
+	ratingStatsRepo repository.RatingStatsRepository
+	minReviews      int
+
+	mu       sync.RWMutex
+	rankings *entity.Rankings
+	// refreshMu 再計算が同時に走らないようにする
+	refreshMu sync.Mutex
+}
+
+func NewRankingInteractor(rankingRepo repository.RankingRepository, ratingStatsRepo repository.RatingStatsRepository, minReviews int) interfaces.RankingUseCase {
+	return &RankingInteractor{
+		rankingRepo:     rankingRepo,
+		ratingStatsRepo: ratingStatsRepo,
+		minReviews:      minReviews,
+	}
+}
+
+func (i *RankingInteractor) GetSideMenuRanking(category string, limit int) ([]*entity.SideMenuRanking, *entity.Rankings, error) {
+	rankings, err := i.currentRankings(category)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries := rankings.SideMenus[category]
+	return entries[:rankingLimit(limit, len(entries))], rankings, nil
+}
+
+func (i *RankingInteractor) GetStoreRanking(category string, limit int) ([]*entity.StoreRanking, *entity.Rankings, error) {
+	rankings, err := i.currentRankings(category)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries := rankings.Stores[category]
+	return entries[:rankingLimit(limit, len(entries))], rankings, nil
+}
+
+func (i *RankingInteractor) RefreshRankings() error {
+	i.refreshMu.Lock()
+	defer i.refreshMu.Unlock()
+
+	overall, err := i.ratingStatsRepo.Get(entity.RatingSubjectOverall, 0)
+	if err != nil {
+		return fmt.Errorf("評価の集計の取得に失敗しました: %w", err)
+	}
+	priorMean := 3.0
+	if overall.ReviewCount > 0 {
+		priorMean = overall.Average()
+	}
+
+	rankings := &entity.Rankings{
+		SideMenus:   make(map[string][]*entity.SideMenuRanking, len(rankingCategories)),
+		Stores:      make(map[string][]*entity.StoreRanking, len(rankingCategories)),
+		MinReviews:  i.minReviews,
+		GeneratedAt: time.Now(),
+	}
+	for _, category := range rankingCategories {
+		params := entity.RankingParams{
+			Category:   category,
+			MinReviews: i.minReviews,
+			PriorMean:  priorMean,
+			Limit:      entity.MaxRankingLimit,
+		}
+		sideMenus, err := i.rankingRepo.RankSideMenus(params)
+		if err != nil {
+			return fmt.Errorf("サイドメニューランキングの集計に失敗しました: %w", err)
+		}
+		stores, err := i.rankingRepo.RankStores(params)
+		if err != nil {
+			return fmt.Errorf("店舗ランキングの集計に失敗しました: %w", err)
+		}
+		rankings.SideMenus[category] = sideMenus
+		rankings.Stores[category] = stores
+	}
+
+	i.mu.Lock()
+	i.rankings = rankings
+	i.mu.Unlock()
+	return nil
+}
+
+// currentRankings キャッシュ済みのランキングを返す（まだ計算していない場合はその場で計算する）
+func (i *RankingInteractor) currentRankings(category string) (*entity.Rankings, error) {
+	if category != "" && !entity.IsValidSideMenuCategory(category) {
+		return nil, fmt.Errorf("%w: カテゴリ %s はサポートされていません", entity.ErrInvalidCriteria, category)
+	}
+
+	i.mu.RLock()
+	rankings := i.rankings
+	i.mu.RUnlock()
+	if rankings != nil {
+		return rankings, nil
+	}
+
+	if err := i.RefreshRankings(); err != nil {
+		return nil, err
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.rankings, nil
+}
+
+func rankingLimit(limit, size int) int {
+	if limit <= 0 {
+		limit = entity.DefaultRankingLimit
+	}
+	if limit > size {
+		limit = size
+	}
+	return limit
+}
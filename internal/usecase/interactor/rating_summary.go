@@ -0,0 +1,23 @@
+package interactor
+
+import (
+	"fmt"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+)
+
+// ratingSummary 対象の評価集計を、全体平均で補正したベイズ平均と一緒に返す
+func ratingSummary(ratingStatsRepo repository.RatingStatsRepository, subjectType string, subjectID uint) (*entity.RatingSummary, error) {
+	stats, err := ratingStatsRepo.Get(subjectType, subjectID)
+	if err != nil {
+		return nil, fmt.Errorf("評価の集計の取得に失敗しました: %w", err)
+	}
+	overall, err := ratingStatsRepo.Get(entity.RatingSubjectOverall, 0)
+	if err != nil {
+		return nil, fmt.Errorf("評価の集計の取得に失敗しました: %w", err)
+	}
+
+	summary := stats.Summary(overall)
+	return &summary, nil
+}
@@ -0,0 +1,143 @@
+package interactor
+
+import (
+	"fmt"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+type ReactionInteractor struct {
+	reactionRepo      repository.ReactionRepository
+	reviewRepo        repository.ReviewRepository
+	reviewCommentRepo repository.ReviewCommentRepository
+}
+
+func NewReactionInteractor(reactionRepo repository.ReactionRepository, reviewRepo repository.ReviewRepository, reviewCommentRepo repository.ReviewCommentRepository) interfaces.ReactionUseCase {
+	return &ReactionInteractor{
+		reactionRepo:      reactionRepo,
+		reviewRepo:        reviewRepo,
+		reviewCommentRepo: reviewCommentRepo,
+	}
+}
+
+func (i *ReactionInteractor) ListReactionTypes() []entity.ReactionTypeInfo {
+	return entity.ReactionTypes()
+}
+
+func (i *ReactionInteractor) ToggleReaction(targetType entity.ReactionTargetType, targetID uint, reactionType entity.ReactionType, userID uint) (*entity.ReactionToggleResult, error) {
+	if err := entity.ValidateReactionType(reactionType); err != nil {
+		return nil, err
+	}
+	if err := i.checkTarget(targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	removed, err := i.reactionRepo.RemoveReaction(targetType, targetID, userID, reactionType)
+	if err != nil {
+		return nil, fmt.Errorf("リアクションの取り消しに失敗しました: %w", err)
+	}
+	if !removed {
+		reaction := &entity.Reaction{
+			TargetType: targetType,
+			TargetID:   targetID,
+			UserID:     userID,
+			Type:       reactionType,
+		}
+		if _, err := i.reactionRepo.AddReaction(reaction); err != nil {
+			return nil, fmt.Errorf("リアクションの登録に失敗しました: %w", err)
+		}
+	}
+
+	counts, mine, err := i.loadReactions(targetType, []uint{targetID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := &entity.ReactionToggleResult{
+		Type:        reactionType,
+		Reacted:     !removed,
+		Reactions:   countsOf(counts, targetID),
+		MyReactions: mine[targetID],
+	}
+	if result.MyReactions == nil {
+		result.MyReactions = []entity.ReactionType{}
+	}
+	return result, nil
+}
+
+func (i *ReactionInteractor) AttachReviewReactions(reviews []*entity.SideMenuReview, userID uint) error {
+	ids := make([]uint, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.ID)
+	}
+
+	counts, mine, err := i.loadReactions(entity.ReactionTargetReview, ids, userID)
+	if err != nil {
+		return err
+	}
+	for _, review := range reviews {
+		review.Reactions = countsOf(counts, review.ID)
+		review.MyReactions = mine[review.ID]
+	}
+	return nil
+}
+
+func (i *ReactionInteractor) AttachCommentReactions(comments []*entity.ReviewComment, userID uint) error {
+	ids := make([]uint, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+
+	counts, mine, err := i.loadReactions(entity.ReactionTargetComment, ids, userID)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		comment.Reactions = countsOf(counts, comment.ID)
+		comment.MyReactions = mine[comment.ID]
+	}
+	return nil
+}
+
+// checkTarget リアクションを付ける対象が存在するか確認する（削除済みのプレースホルダーのコメントは対象外）
+func (i *ReactionInteractor) checkTarget(targetType entity.ReactionTargetType, targetID uint) error {
+	switch targetType {
+	case entity.ReactionTargetReview:
+		if _, err := i.reviewRepo.GetReviewByID(targetID); err != nil {
+			return &entity.NotFoundError{Resource: resourceReview}
+		}
+	case entity.ReactionTargetComment:
+		comment, err := i.reviewCommentRepo.GetReviewCommentByID(targetID)
+		if err != nil || comment.IsDeleted {
+			return &entity.NotFoundError{Resource: resourceComment}
+		}
+	default:
+		return fmt.Errorf("%w: リアクションの対象 %s はサポートされていません", entity.ErrInvalidRequest, targetType)
+	}
+	return nil
+}
+
+// loadReactions 対象ごとのリアクションの件数と、userID が 0 以外の場合はそのユーザーのリアクションを取得する
+func (i *ReactionInteractor) loadReactions(targetType entity.ReactionTargetType, ids []uint, userID uint) (map[uint]entity.ReactionCounts, map[uint][]entity.ReactionType, error) {
+	counts, err := i.reactionRepo.CountReactions(targetType, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("リアクションの件数の取得に失敗しました: %w", err)
+	}
+	if userID == 0 {
+		return counts, nil, nil
+	}
+	mine, err := i.reactionRepo.GetUserReactions(targetType, ids, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("リアクションの状態の取得に失敗しました: %w", err)
+	}
+	return counts, mine, nil
+}
+
+// countsOf リアクションがない対象も空の件数として返す
+func countsOf(counts map[uint]entity.ReactionCounts, id uint) entity.ReactionCounts {
+	if c, ok := counts[id]; ok {
+		return c
+	}
+	return entity.ReactionCounts{}
+}
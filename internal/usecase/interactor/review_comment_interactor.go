@@ -9,21 +9,47 @@ import (
 )
 
 type ReviewCommentInteractor struct {
-	reviewCommentRepo repository.ReviewCommentRepository
+	reviewCommentRepo       repository.ReviewCommentRepository
+	userRepo                repository.UserRepository
+	emailVerificationPolicy interfaces.EmailVerificationPolicy
+	authorizationService    interfaces.AuthorizationService
+	notificationPublisher   interfaces.NotificationPublisher
+	eventPublisher          interfaces.EventPublisher
 }
 
-func NewReviewCommentInteractor(reviewCommentRepo repository.ReviewCommentRepository) interfaces.ReviewCommentUseCase {
+func NewReviewCommentInteractor(reviewCommentRepo repository.ReviewCommentRepository, userRepo repository.UserRepository, emailVerificationPolicy interfaces.EmailVerificationPolicy, authorizationService interfaces.AuthorizationService, notificationPublisher interfaces.NotificationPublisher, eventPublisher interfaces.EventPublisher) interfaces.ReviewCommentUseCase {
 	return &ReviewCommentInteractor{
-		reviewCommentRepo: reviewCommentRepo,
+		reviewCommentRepo:       reviewCommentRepo,
+		userRepo:                userRepo,
+		emailVerificationPolicy: emailVerificationPolicy,
+		authorizationService:    authorizationService,
+		notificationPublisher:   notificationPublisher,
+		eventPublisher:          eventPublisher,
 	}
 }
 
 func (i *ReviewCommentInteractor) CreateReviewComment(req *entity.CreateReviewCommentRequest, userID uint) (*entity.ReviewComment, error) {
+	if err := i.emailVerificationPolicy.CheckCanPost(userID); err != nil {
+		return nil, err
+	}
+
 	comment := &entity.ReviewComment{
 		ReviewID: req.ReviewID,
 		UserID:   userID,
 		Comment:  req.Comment,
 	}
+	var parent *entity.ReviewComment
+	if req.ParentID != nil {
+		var err error
+		if parent, err = i.attachParent(comment, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	mentions, err := i.resolveMentions(req.Comment, userID)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentions
 
 	if err := i.reviewCommentRepo.CreateReviewComment(comment); err != nil {
 		return nil, fmt.Errorf("レビューコメントの作成に失敗しました: %w", err)
@@ -35,9 +61,84 @@ func (i *ReviewCommentInteractor) CreateReviewComment(req *entity.CreateReviewCo
 		return nil, fmt.Errorf("作成されたレビューコメントの取得に失敗しました: %w", err)
 	}
 
+	i.eventPublisher.Publish(entity.ReviewTopic(createdComment.ReviewID), &entity.Event{Type: entity.EventCommentCreated, Data: createdComment})
+	i.notifyNewComment(createdComment, parent)
 	return createdComment, nil
 }
 
+// notifyNewComment メンションされたユーザー、返信先の投稿者、レビューの投稿者の順に通知する
+// 1件のコメントで同じユーザーに届く通知は1件までとし、先の種類を受信しない設定の場合は次の種類で通知する。
+func (i *ReviewCommentInteractor) notifyNewComment(comment *entity.ReviewComment, parent *entity.ReviewComment) {
+	notified := map[uint]bool{comment.UserID: true}
+	notify := func(userID uint, notificationType entity.NotificationType) {
+		if notified[userID] {
+			return
+		}
+		notified[userID] = publishNotification(i.notificationPublisher, &entity.Notification{
+			UserID:    userID,
+			Type:      notificationType,
+			ActorID:   &comment.UserID,
+			ReviewID:  &comment.ReviewID,
+			CommentID: &comment.ID,
+		})
+	}
+
+	for _, mention := range comment.Mentions {
+		notify(mention.UserID, entity.NotificationMentioned)
+	}
+	if parent != nil {
+		notify(parent.UserID, entity.NotificationCommentReplied)
+	}
+	// レビューが削除済みで読み込めなかった場合は、投稿者が分からないため通知しない
+	if comment.Review.ID != 0 {
+		notify(comment.Review.UserID, entity.NotificationReviewCommented)
+	}
+}
+
+// attachParent 返信先を検証し、スレッド内の位置を設定する
+// 作成までに返信先が削除される場合に備えて、リポジトリが作成のトランザクション内でもう一度検証する。
+func (i *ReviewCommentInteractor) attachParent(comment *entity.ReviewComment, parentID uint) (*entity.ReviewComment, error) {
+	parent, err := i.reviewCommentRepo.GetReviewCommentByID(parentID)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: "返信先のコメント"}
+	}
+	if err := comment.AttachParent(parent); err != nil {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// resolveMentions 本文中の @handle を実在するユーザーへのメンションに変換する
+// 存在しないハンドルと投稿者自身へのメンションは無視する。
+func (i *ReviewCommentInteractor) resolveMentions(text string, authorID uint) ([]entity.CommentMention, error) {
+	handles := entity.ParseMentionHandles(text)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+	if len(handles) > entity.MaxMentionsPerComment {
+		return nil, fmt.Errorf("%w: メンションは1件のコメントにつき%d人までです", entity.ErrInvalidRequest, entity.MaxMentionsPerComment)
+	}
+
+	users, err := i.userRepo.GetByHandles(handles)
+	if err != nil {
+		return nil, fmt.Errorf("メンション先のユーザーの取得に失敗しました: %w", err)
+	}
+	byHandle := make(map[string]*entity.User, len(users))
+	for _, user := range users {
+		byHandle[user.Handle] = user
+	}
+
+	var mentions []entity.CommentMention
+	for _, handle := range handles {
+		user, ok := byHandle[handle]
+		if !ok || user.ID == authorID {
+			continue
+		}
+		mentions = append(mentions, entity.CommentMention{UserID: user.ID, Handle: handle})
+	}
+	return mentions, nil
+}
+
 func (i *ReviewCommentInteractor) GetReviewCommentByID(id uint) (*entity.ReviewComment, error) {
 	comment, err := i.reviewCommentRepo.GetReviewCommentByID(id)
 	if err != nil {
@@ -46,40 +147,132 @@ func (i *ReviewCommentInteractor) GetReviewCommentByID(id uint) (*entity.ReviewC
 	return comment, nil
 }
 
-func (i *ReviewCommentInteractor) GetReviewCommentsByReviewID(reviewID uint) ([]*entity.ReviewComment, error) {
-	comments, err := i.reviewCommentRepo.GetReviewCommentsByReviewID(reviewID)
+func (i *ReviewCommentInteractor) GetReviewCommentsByReviewID(reviewID uint, mode entity.ReviewCommentMode, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	if mode != entity.ReviewCommentModeTree {
+		comments, pageInfo, err := i.reviewCommentRepo.GetReviewCommentsByReviewID(reviewID, page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("レビューコメント一覧の取得に失敗しました: %w", err)
+		}
+		return comments, pageInfo, nil
+	}
+
+	roots, pageInfo, err := i.reviewCommentRepo.GetTopLevelReviewCommentsByReviewID(reviewID, page)
 	if err != nil {
-		return nil, fmt.Errorf("レビューコメント一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("レビューコメント一覧の取得に失敗しました: %w", err)
+	}
+	rootIDs := make([]uint, len(roots))
+	for n, root := range roots {
+		rootIDs[n] = root.ID
 	}
-	return comments, nil
+	replies, err := i.reviewCommentRepo.GetRepliesByRootIDs(rootIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("返信の取得に失敗しました: %w", err)
+	}
+	return entity.BuildReviewCommentTree(roots, replies), pageInfo, nil
+}
+
+func (i *ReviewCommentInteractor) GetReplies(commentID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	if _, err := i.reviewCommentRepo.GetReviewCommentByID(commentID); err != nil {
+		return nil, nil, &entity.NotFoundError{Resource: resourceComment}
+	}
+
+	replies, pageInfo, err := i.reviewCommentRepo.GetRepliesByParentID(commentID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("返信一覧の取得に失敗しました: %w", err)
+	}
+	return replies, pageInfo, nil
 }
 
-func (i *ReviewCommentInteractor) GetReviewCommentsByUserID(userID uint) ([]*entity.ReviewComment, error) {
-	comments, err := i.reviewCommentRepo.GetReviewCommentsByUserID(userID)
+func (i *ReviewCommentInteractor) GetReviewCommentsByUserID(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	comments, pageInfo, err := i.reviewCommentRepo.GetReviewCommentsByUserID(userID, page)
 	if err != nil {
-		return nil, fmt.Errorf("ユーザーのレビューコメント一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("ユーザーのレビューコメント一覧の取得に失敗しました: %w", err)
 	}
-	return comments, nil
+	return comments, pageInfo, nil
 }
 
-func (i *ReviewCommentInteractor) GetAllReviewComments() ([]*entity.ReviewComment, error) {
-	comments, err := i.reviewCommentRepo.GetAllReviewComments()
+func (i *ReviewCommentInteractor) GetMentionsOfUser(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	comments, pageInfo, err := i.reviewCommentRepo.GetReviewCommentsMentioningUser(userID, page)
 	if err != nil {
-		return nil, fmt.Errorf("全レビューコメント一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("メンションされたコメント一覧の取得に失敗しました: %w", err)
 	}
-	return comments, nil
+	return comments, pageInfo, nil
 }
 
-func (i *ReviewCommentInteractor) UpdateReviewComment(comment *entity.ReviewComment) error {
+func (i *ReviewCommentInteractor) GetAllReviewComments(page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error) {
+	comments, pageInfo, err := i.reviewCommentRepo.GetAllReviewComments(page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("全レビューコメント一覧の取得に失敗しました: %w", err)
+	}
+	return comments, pageInfo, nil
+}
+
+func (i *ReviewCommentInteractor) UpdateReviewComment(id uint, req *entity.UpdateReviewCommentRequest, actor *entity.Actor) (*entity.ReviewComment, error) {
+	comment, err := i.authorizationService.AuthorizeReviewComment(id, actor, entity.ActionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	// モデレーターが編集した場合も、メンションしたのはコメントの投稿者として扱う
+	mentions, err := i.resolveMentions(req.Comment, comment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[uint]bool, len(comment.Mentions))
+	for _, mention := range comment.Mentions {
+		previous[mention.UserID] = true
+	}
+
+	comment.Comment = req.Comment
+	comment.Mentions = mentions
 	if err := i.reviewCommentRepo.UpdateReviewComment(comment); err != nil {
-		return fmt.Errorf("レビューコメントの更新に失敗しました: %w", err)
+		return nil, fmt.Errorf("レビューコメントの更新に失敗しました: %w", err)
 	}
-	return nil
+
+	i.eventPublisher.Publish(entity.ReviewTopic(comment.ReviewID), &entity.Event{Type: entity.EventCommentUpdated, Data: comment})
+
+	// 編集で新しくメンションされたユーザーにのみ通知する
+	for _, mention := range mentions {
+		if previous[mention.UserID] {
+			continue
+		}
+		publishNotification(i.notificationPublisher, &entity.Notification{
+			UserID:    mention.UserID,
+			Type:      entity.NotificationMentioned,
+			ActorID:   &comment.UserID,
+			ReviewID:  &comment.ReviewID,
+			CommentID: &comment.ID,
+		})
+	}
+	if actor.UserID != comment.UserID {
+		notification := moderationNotification(comment.UserID, "あなたのコメントがモデレーターにより編集されました")
+		notification.ReviewID = &comment.ReviewID
+		notification.CommentID = &comment.ID
+		publishNotification(i.notificationPublisher, notification)
+	}
+	return comment, nil
 }
 
-func (i *ReviewCommentInteractor) DeleteReviewComment(id uint) error {
+func (i *ReviewCommentInteractor) DeleteReviewComment(id uint, actor *entity.Actor) error {
+	comment, err := i.authorizationService.AuthorizeReviewComment(id, actor, entity.ActionDelete)
+	if err != nil {
+		return err
+	}
+
 	if err := i.reviewCommentRepo.DeleteReviewComment(id); err != nil {
 		return fmt.Errorf("レビューコメントの削除に失敗しました: %w", err)
 	}
+
+	// 返信が残っている場合はプレースホルダーになるため、クライアントはスレッドを取得し直す
+	i.eventPublisher.Publish(entity.ReviewTopic(comment.ReviewID), &entity.Event{
+		Type: entity.EventCommentDeleted,
+		Data: &entity.DeletedData{ID: comment.ID, ReviewID: comment.ReviewID},
+	})
+
+	if actor.UserID != comment.UserID {
+		notification := moderationNotification(comment.UserID, "あなたのコメントがモデレーターにより削除されました")
+		notification.ReviewID = &comment.ReviewID
+		publishNotification(i.notificationPublisher, notification)
+	}
 	return nil
 }
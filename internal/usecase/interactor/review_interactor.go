@@ -2,6 +2,7 @@ package interactor
 
 import (
 	"fmt"
+	"time"
 
 	"sidemenulab-backend/internal/domain/entity"
 	"sidemenulab-backend/internal/domain/repository"
@@ -9,12 +10,26 @@ import (
 )
 
 type ReviewInteractor struct {
-	reviewRepo repository.ReviewRepository
+	reviewRepo              repository.ReviewRepository
+	storeRepo               repository.StoreRepository
+	sideMenuRepo            repository.SideMenuRepository
+	emailVerificationPolicy interfaces.EmailVerificationPolicy
+	authorizationService    interfaces.AuthorizationService
+	notificationPublisher   interfaces.NotificationPublisher
+	eventPublisher          interfaces.EventPublisher
+	trendingPolicy          entity.TrendingPolicy
 }
 
-func NewReviewInteractor(reviewRepo repository.ReviewRepository) interfaces.ReviewUseCase {
+func NewReviewInteractor(reviewRepo repository.ReviewRepository, storeRepo repository.StoreRepository, sideMenuRepo repository.SideMenuRepository, emailVerificationPolicy interfaces.EmailVerificationPolicy, authorizationService interfaces.AuthorizationService, notificationPublisher interfaces.NotificationPublisher, eventPublisher interfaces.EventPublisher, trendingPolicy entity.TrendingPolicy) interfaces.ReviewUseCase {
 	return &ReviewInteractor{
-		reviewRepo: reviewRepo,
+		reviewRepo:              reviewRepo,
+		storeRepo:               storeRepo,
+		sideMenuRepo:            sideMenuRepo,
+		emailVerificationPolicy: emailVerificationPolicy,
+		authorizationService:    authorizationService,
+		notificationPublisher:   notificationPublisher,
+		eventPublisher:          eventPublisher,
+		trendingPolicy:          trendingPolicy,
 	}
 }
 
@@ -23,15 +38,29 @@ func (i *ReviewInteractor) CreateReview(req *entity.CreateReviewRequest) (*entit
 	// 現在は仮でuserID=1を使用
 	userID := uint(1)
 
+	if err := i.emailVerificationPolicy.CheckCanPost(userID); err != nil {
+		return nil, err
+	}
+
+	menu, err := i.resolveSideMenu(req)
+	if err != nil {
+		return nil, err
+	}
+
 	review := &entity.SideMenuReview{
-		StoreName:    req.StoreName,
-		SideMenuName: req.SideMenuName,
+		StoreID:      &menu.StoreID,
+		StoreName:    menu.Store.Name,
+		SideMenuID:   &menu.ID,
+		SideMenuName: menu.Name,
 		UserID:       userID,
 		Rating:       req.Rating,
 		Title:        req.Title,
 		Comment:      req.Comment,
 		IsVerified:   false, // デフォルトで未確認
+		CreatedAt:    time.Now(),
 	}
+	// 次の定期ジョブを待たずにトレンドに載るよう、反応のない状態のスコアを設定する
+	review.TrendingScore = i.trendingPolicy.Score(0, 0, review.CreatedAt)
 
 	if err := i.reviewRepo.CreateReview(review); err != nil {
 		return nil, fmt.Errorf("レビューの作成に失敗しました: %w", err)
@@ -47,15 +76,29 @@ func (i *ReviewInteractor) CreateReview(req *entity.CreateReviewRequest) (*entit
 }
 
 func (i *ReviewInteractor) CreateReviewWithUserID(req *entity.CreateReviewRequest, userID uint) (*entity.SideMenuReview, error) {
+	if err := i.emailVerificationPolicy.CheckCanPost(userID); err != nil {
+		return nil, err
+	}
+
+	menu, err := i.resolveSideMenu(req)
+	if err != nil {
+		return nil, err
+	}
+
 	review := &entity.SideMenuReview{
-		StoreName:    req.StoreName,
-		SideMenuName: req.SideMenuName,
+		StoreID:      &menu.StoreID,
+		StoreName:    menu.Store.Name,
+		SideMenuID:   &menu.ID,
+		SideMenuName: menu.Name,
 		UserID:       userID,
 		Rating:       req.Rating,
 		Title:        req.Title,
 		Comment:      req.Comment,
 		IsVerified:   false, // デフォルトで未確認
+		CreatedAt:    time.Now(),
 	}
+	// 次の定期ジョブを待たずにトレンドに載るよう、反応のない状態のスコアを設定する
+	review.TrendingScore = i.trendingPolicy.Score(0, 0, review.CreatedAt)
 
 	if err := i.reviewRepo.CreateReview(review); err != nil {
 		return nil, fmt.Errorf("レビューの作成に失敗しました: %w", err)
@@ -78,43 +121,100 @@ func (i *ReviewInteractor) GetReviewByID(id uint) (*entity.SideMenuReview, error
 	return review, nil
 }
 
-func (i *ReviewInteractor) GetReviewsByStoreName(storeName string) ([]*entity.SideMenuReview, error) {
-	reviews, err := i.reviewRepo.GetReviewsByStoreName(storeName)
+// GetReviewsByStoreName 店舗名（別名を含む）に一致する店舗のレビュー一覧を取得する
+// 店舗に紐付いていない古いレビューは店舗名の完全一致で探す。
+func (i *ReviewInteractor) GetReviewsByStoreName(storeName string, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	store, err := i.storeRepo.FindByName(storeName)
 	if err != nil {
-		return nil, fmt.Errorf("店舗のレビュー一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("店舗の取得に失敗しました: %w", err)
 	}
-	return reviews, nil
+
+	var reviews []*entity.SideMenuReview
+	var pageInfo *entity.PageInfo
+	if store != nil {
+		reviews, pageInfo, err = i.reviewRepo.GetReviewsByStoreID(store.ID, page)
+	} else {
+		reviews, pageInfo, err = i.reviewRepo.GetReviewsByStoreName(storeName, page)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("店舗のレビュー一覧の取得に失敗しました: %w", err)
+	}
+	return reviews, pageInfo, nil
 }
 
-func (i *ReviewInteractor) GetReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error) {
-	reviews, err := i.reviewRepo.GetReviewsByUserID(userID)
+func (i *ReviewInteractor) GetReviewsBySideMenuID(sideMenuID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	if _, err := i.sideMenuRepo.GetByID(sideMenuID); err != nil {
+		return nil, nil, &entity.NotFoundError{Resource: resourceSideMenu}
+	}
+
+	reviews, pageInfo, err := i.reviewRepo.GetReviewsBySideMenuID(sideMenuID, page)
 	if err != nil {
-		return nil, fmt.Errorf("ユーザーのレビュー一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("サイドメニューのレビュー一覧の取得に失敗しました: %w", err)
 	}
-	return reviews, nil
+	return reviews, pageInfo, nil
 }
 
-func (i *ReviewInteractor) GetAllReviews() ([]*entity.SideMenuReview, error) {
-	reviews, err := i.reviewRepo.GetAllReviews()
+func (i *ReviewInteractor) GetReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	reviews, pageInfo, err := i.reviewRepo.GetReviewsByUserID(userID, page)
 	if err != nil {
-		return nil, fmt.Errorf("レビュー一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("ユーザーのレビュー一覧の取得に失敗しました: %w", err)
 	}
-	return reviews, nil
+	return reviews, pageInfo, nil
 }
 
-func (i *ReviewInteractor) GetLikedReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error) {
-	reviews, err := i.reviewRepo.GetLikedReviewsByUserID(userID)
+// SearchReviews 条件で絞り込んだレビュー一覧を指定の並び順で取得する
+func (i *ReviewInteractor) SearchReviews(criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, nil, err
+	}
+	// 店舗名の指定は別名も含めて店舗に名寄せする
+	if criteria.StoreName != "" {
+		store, err := i.storeRepo.FindByName(criteria.StoreName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("店舗の取得に失敗しました: %w", err)
+		}
+		if store != nil {
+			criteria.StoreID = store.ID
+			criteria.StoreName = ""
+		}
+	}
+
+	reviews, pageInfo, err := i.reviewRepo.SearchReviews(criteria, page)
 	if err != nil {
-		return nil, fmt.Errorf("ユーザーがいいねしたレビュー一覧の取得に失敗しました: %w", err)
+		return nil, nil, fmt.Errorf("レビュー一覧の取得に失敗しました: %w", err)
 	}
-	return reviews, nil
+	return reviews, pageInfo, nil
 }
 
-func (i *ReviewInteractor) CreateReviewImage(req *entity.CreateReviewImageRequest) (*entity.SideMenuReviewImage, error) {
+func (i *ReviewInteractor) GetLikedReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	reviews, pageInfo, err := i.reviewRepo.GetLikedReviewsByUserID(userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ユーザーがいいねしたレビュー一覧の取得に失敗しました: %w", err)
+	}
+	return reviews, pageInfo, nil
+}
+
+// AuthorizeReviewImageUpload 画像のアップロード前に、レビューへ画像を追加する権限があるか確認する
+func (i *ReviewInteractor) AuthorizeReviewImageUpload(reviewID uint, actor *entity.Actor) error {
+	_, err := i.authorizationService.AuthorizeReview(reviewID, actor, entity.ActionAddImage)
+	return err
+}
+
+func (i *ReviewInteractor) CreateReviewImage(req *entity.CreateReviewImageRequest, actor *entity.Actor) (*entity.SideMenuReviewImage, error) {
+	if _, err := i.authorizationService.AuthorizeReview(req.ReviewID, actor, entity.ActionAddImage); err != nil {
+		return nil, err
+	}
+
 	image := &entity.SideMenuReviewImage{
-		ReviewID:   req.ReviewID,
-		ImageURL:   req.ImageURL,
-		ImageOrder: req.ImageOrder,
+		ReviewID:        req.ReviewID,
+		ImageURL:        req.ImageURL,
+		ImageOrder:      req.ImageOrder,
+		StorageProvider: req.StorageProvider,
+		StorageKey:      req.StorageKey,
+		Width:           req.Width,
+		Height:          req.Height,
+		Format:          req.Format,
+		Bytes:           req.Bytes,
 	}
 
 	if err := i.reviewRepo.CreateReviewImage(image); err != nil {
@@ -132,51 +232,195 @@ func (i *ReviewInteractor) GetReviewImagesByReviewID(reviewID uint) ([]*entity.S
 	return images, nil
 }
 
-func (i *ReviewInteractor) UpdateReview(review *entity.SideMenuReview) error {
+func (i *ReviewInteractor) UpdateReview(id uint, req *entity.CreateReviewRequest, actor *entity.Actor) (*entity.SideMenuReview, error) {
+	review, err := i.authorizationService.AuthorizeReview(id, actor, entity.ActionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := i.resolveSideMenu(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 関連を差し替えないと、保存時に読み込み済みの関連のIDで外部キーが上書きされる
+	review.StoreID = &menu.StoreID
+	review.Store = menu.Store
+	review.StoreName = menu.Store.Name
+	review.SideMenuID = &menu.ID
+	review.SideMenu = menu
+	review.SideMenuName = menu.Name
+	review.Rating = req.Rating
+	review.Title = req.Title
+	review.Comment = req.Comment
+
 	if err := i.reviewRepo.UpdateReview(review); err != nil {
-		return fmt.Errorf("レビューの更新に失敗しました: %w", err)
+		return nil, fmt.Errorf("レビューの更新に失敗しました: %w", err)
 	}
-	return nil
+
+	i.eventPublisher.Publish(entity.ReviewTopic(review.ID), &entity.Event{Type: entity.EventReviewUpdated, Data: review})
+	if actor.UserID != review.UserID {
+		notification := moderationNotification(review.UserID, "あなたのレビューがモデレーターにより編集されました")
+		notification.ReviewID = &review.ID
+		publishNotification(i.notificationPublisher, notification)
+	}
+	return review, nil
 }
 
-func (i *ReviewInteractor) DeleteReview(id uint) error {
+func (i *ReviewInteractor) DeleteReview(id uint, actor *entity.Actor) error {
+	review, err := i.authorizationService.AuthorizeReview(id, actor, entity.ActionDelete)
+	if err != nil {
+		return err
+	}
+
 	if err := i.reviewRepo.DeleteReview(id); err != nil {
 		return fmt.Errorf("レビューの削除に失敗しました: %w", err)
 	}
+
+	i.eventPublisher.Publish(entity.ReviewTopic(review.ID), &entity.Event{Type: entity.EventReviewDeleted, Data: &entity.DeletedData{ID: review.ID, ReviewID: review.ID}})
+
+	if actor.UserID != review.UserID {
+		publishNotification(i.notificationPublisher, moderationNotification(review.UserID, "あなたのレビューがモデレーターにより削除されました"))
+	}
 	return nil
 }
 
-func (i *ReviewInteractor) DeleteReviewImage(imageID uint) error {
+func (i *ReviewInteractor) DeleteReviewImage(imageID uint, actor *entity.Actor) error {
+	if _, err := i.authorizationService.AuthorizeReviewImage(imageID, actor, entity.ActionDelete); err != nil {
+		return err
+	}
+
 	if err := i.reviewRepo.DeleteReviewImage(imageID); err != nil {
 		return fmt.Errorf("レビュー画像の削除に失敗しました: %w", err)
 	}
 	return nil
 }
 
-func (i *ReviewInteractor) CreateReviewLike(reviewID uint, userID uint) (*entity.SideMenuReviewLike, error) {
+// CreateReviewLike レビューにいいねする（既にいいねしている場合は既存のいいねを返す）
+func (i *ReviewInteractor) CreateReviewLike(reviewID uint, userID uint) (*entity.SideMenuReviewLike, bool, error) {
+	review, err := i.reviewRepo.GetReviewByID(reviewID)
+	if err != nil {
+		return nil, false, &entity.NotFoundError{Resource: resourceReview}
+	}
+
 	like := &entity.SideMenuReviewLike{
 		ReviewID: reviewID,
 		UserID:   userID,
 	}
 
-	if err := i.reviewRepo.CreateReviewLike(like); err != nil {
-		return nil, fmt.Errorf("レビューのイイネに失敗しました: %w", err)
+	created, err := i.reviewRepo.CreateReviewLike(like)
+	if err != nil {
+		return nil, false, fmt.Errorf("レビューのイイネに失敗しました: %w", err)
+	}
+
+	// 重複したいいねでは通知しない。取り消してからいいねし直した場合は、一定期間内の通知をまとめる（NotificationType.DedupWindow）
+	if created {
+		i.eventPublisher.Publish(entity.ReviewTopic(review.ID), &entity.Event{
+			Type: entity.EventReviewLiked,
+			Data: &entity.ReviewLikeData{ReviewID: reviewID, UserID: userID},
+		})
+		publishNotification(i.notificationPublisher, &entity.Notification{
+			UserID:   review.UserID,
+			Type:     entity.NotificationReviewLiked,
+			ActorID:  &userID,
+			ReviewID: &review.ID,
+		})
 	}
 
-	return like, nil
+	return like, created, nil
 }
 
+// DeleteReviewLike いいねを取り消す（いいねしていない場合も成功とする）
 func (i *ReviewInteractor) DeleteReviewLike(reviewID uint, userID uint) error {
-	if err := i.reviewRepo.DeleteReviewLike(reviewID, userID); err != nil {
+	if _, err := i.reviewRepo.GetReviewByID(reviewID); err != nil {
+		return &entity.NotFoundError{Resource: resourceReview}
+	}
+
+	deleted, err := i.reviewRepo.DeleteReviewLike(reviewID, userID)
+	if err != nil {
 		return fmt.Errorf("レビューのイイネ取り消しに失敗しました: %w", err)
 	}
+	if deleted {
+		i.eventPublisher.Publish(entity.ReviewTopic(reviewID), &entity.Event{
+			Type: entity.EventReviewUnliked,
+			Data: &entity.ReviewLikeData{ReviewID: reviewID, UserID: userID},
+		})
+	}
 	return nil
 }
 
-func (i *ReviewInteractor) GetReviewLikesByReviewID(reviewID uint) ([]*entity.SideMenuReviewLike, error) {
-	likes, err := i.reviewRepo.GetReviewLikesByReviewID(reviewID)
+func (i *ReviewInteractor) MarkLikedByUser(reviews []*entity.SideMenuReview, userID uint) error {
+	ids := make([]uint, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.ID)
+	}
+
+	liked, err := i.reviewRepo.GetLikedReviewIDs(userID, ids)
+	if err != nil {
+		return fmt.Errorf("いいねの状態の取得に失敗しました: %w", err)
+	}
+	for _, review := range reviews {
+		review.LikedByMe = liked[review.ID]
+	}
+	return nil
+}
+
+func (i *ReviewInteractor) GetReviewLikesByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.SideMenuReviewLike, *entity.PageInfo, error) {
+	likes, pageInfo, err := i.reviewRepo.GetReviewLikesByReviewID(reviewID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("レビューのイイネ一覧の取得に失敗しました: %w", err)
+	}
+	return likes, pageInfo, nil
+}
+
+// resolveSideMenu レビューの対象となるサイドメニューを店舗と一緒に決める
+// サイドメニューIDの指定を優先し、メニュー名の場合は店舗内で名寄せする（未登録のメニュー名は新しいサイドメニューとして登録する）
+func (i *ReviewInteractor) resolveSideMenu(req *entity.CreateReviewRequest) (*entity.SideMenu, error) {
+	if req.SideMenuID != nil {
+		menu, err := i.sideMenuRepo.GetByID(*req.SideMenuID)
+		if err != nil {
+			return nil, &entity.NotFoundError{Resource: resourceSideMenu}
+		}
+		if req.StoreID != nil && *req.StoreID != menu.StoreID {
+			return nil, fmt.Errorf("%w: サイドメニューが指定された店舗のものではありません", entity.ErrInvalidRequest)
+		}
+		return menu, nil
+	}
+
+	store, err := i.resolveStore(req)
+	if err != nil {
+		return nil, err
+	}
+	if entity.NormalizeStoreName(req.SideMenuName) == "" {
+		return nil, fmt.Errorf("%w: サイドメニュー名が正しくありません", entity.ErrInvalidRequest)
+	}
+
+	menu, err := i.sideMenuRepo.FindOrCreateByName(store.ID, req.SideMenuName)
+	if err != nil {
+		return nil, fmt.Errorf("サイドメニューの登録に失敗しました: %w", err)
+	}
+	menu.Store = store
+	return menu, nil
+}
+
+// resolveStore レビューを紐付ける店舗を決める
+// 店舗IDの指定を優先し、店舗名の場合は別名を含めて名寄せする（未登録の店舗名は新しい店舗として登録する）
+func (i *ReviewInteractor) resolveStore(req *entity.CreateReviewRequest) (*entity.Store, error) {
+	if req.StoreID != nil {
+		store, err := i.storeRepo.GetByID(*req.StoreID)
+		if err != nil {
+			return nil, &entity.NotFoundError{Resource: resourceStore}
+		}
+		return store, nil
+	}
+
+	if entity.NormalizeStoreName(req.StoreName) == "" {
+		return nil, fmt.Errorf("%w: 店舗名が正しくありません", entity.ErrInvalidRequest)
+	}
+
+	store, err := i.storeRepo.FindOrCreateByName(req.StoreName)
 	if err != nil {
-		return nil, fmt.Errorf("レビューのイイネ一覧の取得に失敗しました: %w", err)
+		return nil, fmt.Errorf("店舗の登録に失敗しました: %w", err)
 	}
-	return likes, nil
+	return store, nil
 }
@@ -0,0 +1,177 @@
+package interactor
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// highlightContextRunes コメントの抜粋で一致箇所の前に残す文字数（後ろはこの2倍）
+const highlightContextRunes = 30
+
+type SearchInteractor struct {
+	reviewSearchRepo repository.ReviewSearchRepository
+	variants         entity.SearchVariants
+}
+
+func NewSearchInteractor(reviewSearchRepo repository.ReviewSearchRepository, variants entity.SearchVariants) interfaces.SearchUseCase {
+	return &SearchInteractor{
+		reviewSearchRepo: reviewSearchRepo,
+		variants:         variants,
+	}
+}
+
+func (i *SearchInteractor) SearchReviews(keyword string, criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.ReviewSearchHit, *entity.PageInfo, error) {
+	query, err := entity.NewSearchQuery(keyword, i.variants)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := criteria.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	hits, pageInfo, err := i.reviewSearchRepo.SearchReviews(query, criteria, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("レビューの検索に失敗しました: %w", err)
+	}
+
+	candidates := query.Candidates()
+	for _, hit := range hits {
+		hit.Highlights = highlightReview(hit.Review, candidates)
+	}
+	return hits, pageInfo, nil
+}
+
+// highlightReview 一致した語を含むフィールドごとにハイライトを作る
+func highlightReview(review *entity.SideMenuReview, candidates []string) map[string]string {
+	fields := []struct {
+		name    string
+		value   string
+		excerpt bool
+	}{
+		{"title", review.Title, false},
+		{"side_menu_name", review.SideMenuName, false},
+		{"store_name", review.StoreName, false},
+		{"comment", review.Comment, true},
+	}
+
+	highlights := make(map[string]string)
+	for _, field := range fields {
+		if highlighted, ok := highlight(field.value, candidates, field.excerpt); ok {
+			highlights[field.name] = highlighted
+		}
+	}
+	return highlights
+}
+
+// foldSegment 正規化の単位となる区間（元の文字列と正規化後の文字列の位置の対応）
+type foldSegment struct {
+	folded int
+	start  int
+	end    int
+}
+
+// foldWithOffsets 正規化の境界ごとに FoldSearchText をかけ、元の位置との対応表を作る
+func foldWithOffsets(text string) (string, []foldSegment) {
+	var b strings.Builder
+	var segments []foldSegment
+	for pos := 0; pos < len(text); {
+		n := norm.NFKC.NextBoundaryInString(text[pos:], true)
+		if n <= 0 {
+			n = len(text) - pos
+		}
+		segments = append(segments, foldSegment{folded: b.Len(), start: pos, end: pos + n})
+		b.WriteString(entity.FoldSearchText(text[pos : pos+n]))
+		pos += n
+	}
+	return b.String(), segments
+}
+
+// segmentAt 正規化後の位置 pos を含む区間
+func segmentAt(segments []foldSegment, pos int) foldSegment {
+	i := sort.Search(len(segments), func(i int) bool { return segments[i].folded > pos })
+	return segments[i-1]
+}
+
+// highlight 正規化後の文字列で候補を探し、元の文字列の一致箇所を <mark> で囲む
+// excerpt が true の場合は最初の一致箇所の周辺だけを抜粋する。
+func highlight(text string, candidates []string, excerpt bool) (string, bool) {
+	folded, segments := foldWithOffsets(text)
+
+	var matches [][2]int
+	for pos := 0; pos < len(folded); {
+		length := 0
+		for _, candidate := range candidates {
+			if strings.HasPrefix(folded[pos:], candidate) {
+				length = len(candidate)
+				break
+			}
+		}
+		if length == 0 {
+			_, size := utf8.DecodeRuneInString(folded[pos:])
+			pos += size
+			continue
+		}
+
+		start := segmentAt(segments, pos).start
+		end := segmentAt(segments, pos+length-1).end
+		if n := len(matches); n > 0 && matches[n-1][1] >= start {
+			matches[n-1][1] = end
+		} else {
+			matches = append(matches, [2]int{start, end})
+		}
+		pos += length
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(text)
+	if excerpt {
+		from = moveRunes(text, matches[0][0], -highlightContextRunes)
+		to = moveRunes(text, matches[0][1], 2*highlightContextRunes)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, match := range matches {
+		start, end := max(match[0], from), min(match[1], to)
+		if start >= end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[start:end]))
+		b.WriteString("</mark>")
+		pos = end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// moveRunes バイト位置 pos から n 文字（負の場合は前方向）移動した位置を返す
+func moveRunes(text string, pos int, n int) int {
+	for ; n < 0 && pos > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+	}
+	for ; n > 0 && pos < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
@@ -0,0 +1,149 @@
+package interactor
+
+import (
+	"fmt"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+type SideMenuInteractor struct {
+	sideMenuRepo    repository.SideMenuRepository
+	storeRepo       repository.StoreRepository
+	ratingStatsRepo repository.RatingStatsRepository
+}
+
+func NewSideMenuInteractor(sideMenuRepo repository.SideMenuRepository, storeRepo repository.StoreRepository, ratingStatsRepo repository.RatingStatsRepository) interfaces.SideMenuUseCase {
+	return &SideMenuInteractor{
+		sideMenuRepo:    sideMenuRepo,
+		storeRepo:       storeRepo,
+		ratingStatsRepo: ratingStatsRepo,
+	}
+}
+
+func (i *SideMenuInteractor) CreateSideMenu(req *entity.SideMenuRequest, actor *entity.Actor) (*entity.SideMenu, error) {
+	if !actor.Can(entity.PermissionManageStores) {
+		return nil, forbidden(resourceSideMenu, 0, entity.ActionCreate, actor)
+	}
+
+	menu := &entity.SideMenu{IsAvailable: true}
+	if err := i.applySideMenuRequest(menu, req); err != nil {
+		return nil, err
+	}
+
+	if err := i.sideMenuRepo.Create(menu); err != nil {
+		return nil, fmt.Errorf("サイドメニューの作成に失敗しました: %w", err)
+	}
+	return menu, nil
+}
+
+func (i *SideMenuInteractor) GetSideMenuByID(id uint) (*entity.SideMenuDetail, error) {
+	menu, err := i.sideMenuRepo.GetByID(id)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceSideMenu}
+	}
+
+	stats, err := ratingSummary(i.ratingStatsRepo, entity.RatingSubjectSideMenu, id)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.SideMenuDetail{SideMenu: menu, Stats: *stats}, nil
+}
+
+func (i *SideMenuInteractor) ListSideMenus(filter entity.SideMenuFilter, page entity.PageRequest) ([]*entity.SideMenu, *entity.PageInfo, error) {
+	if filter.Category != "" && !entity.IsValidSideMenuCategory(filter.Category) {
+		return nil, nil, fmt.Errorf("%w: カテゴリ %s はサポートされていません", entity.ErrInvalidCriteria, filter.Category)
+	}
+
+	menus, pageInfo, err := i.sideMenuRepo.List(filter, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("サイドメニュー一覧の取得に失敗しました: %w", err)
+	}
+	return menus, pageInfo, nil
+}
+
+func (i *SideMenuInteractor) UpdateSideMenu(id uint, req *entity.SideMenuRequest, actor *entity.Actor) (*entity.SideMenu, error) {
+	if !actor.Can(entity.PermissionManageStores) {
+		return nil, forbidden(resourceSideMenu, id, entity.ActionEdit, actor)
+	}
+
+	menu, err := i.sideMenuRepo.GetByID(id)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceSideMenu}
+	}
+
+	if err := i.applySideMenuRequest(menu, req); err != nil {
+		return nil, err
+	}
+
+	if err := i.sideMenuRepo.Update(menu); err != nil {
+		return nil, fmt.Errorf("サイドメニューの更新に失敗しました: %w", err)
+	}
+	return menu, nil
+}
+
+func (i *SideMenuInteractor) DeleteSideMenu(id uint, actor *entity.Actor) error {
+	if !actor.Can(entity.PermissionManageStores) {
+		return forbidden(resourceSideMenu, id, entity.ActionDelete, actor)
+	}
+
+	if _, err := i.sideMenuRepo.GetByID(id); err != nil {
+		return &entity.NotFoundError{Resource: resourceSideMenu}
+	}
+
+	count, err := i.sideMenuRepo.CountReviews(id)
+	if err != nil {
+		return fmt.Errorf("サイドメニューのレビュー件数の取得に失敗しました: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: レビューが投稿されているサイドメニューは削除できません（販売終了の場合は is_available を false にしてください）", entity.ErrConflict)
+	}
+
+	if err := i.sideMenuRepo.Delete(id); err != nil {
+		return fmt.Errorf("サイドメニューの削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// applySideMenuRequest 店舗の存在と店舗内でのメニュー名の重複を確認してから、リクエストの内容を反映する
+// レビューのあるサイドメニューは、レビューの店舗や店舗の評価の集計とずれるため別の店舗に移せない。
+func (i *SideMenuInteractor) applySideMenuRequest(menu *entity.SideMenu, req *entity.SideMenuRequest) error {
+	store, err := i.storeRepo.GetByID(req.StoreID)
+	if err != nil {
+		return &entity.NotFoundError{Resource: resourceStore}
+	}
+	if menu.ID != 0 && menu.StoreID != store.ID {
+		count, err := i.sideMenuRepo.CountReviews(menu.ID)
+		if err != nil {
+			return fmt.Errorf("サイドメニューのレビュー件数の取得に失敗しました: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: レビューが投稿されているサイドメニューは別の店舗に移動できません", entity.ErrConflict)
+		}
+	}
+	if entity.NormalizeStoreName(req.Name) == "" {
+		return fmt.Errorf("%w: サイドメニュー名が正しくありません", entity.ErrInvalidRequest)
+	}
+
+	existing, err := i.sideMenuRepo.FindByName(store.ID, req.Name)
+	if err != nil {
+		return fmt.Errorf("サイドメニューの取得に失敗しました: %w", err)
+	}
+	if existing != nil && existing.ID != menu.ID {
+		return fmt.Errorf("%w: 「%s」は既にこの店舗のサイドメニューとして登録されています", entity.ErrConflict, req.Name)
+	}
+
+	menu.StoreID = store.ID
+	menu.Store = store
+	menu.Name = strings.TrimSpace(req.Name)
+	menu.Description = req.Description
+	menu.Category = req.Category
+	menu.Price = req.Price
+	menu.Calories = req.Calories
+	if req.IsAvailable != nil {
+		menu.IsAvailable = *req.IsAvailable
+	}
+	return nil
+}
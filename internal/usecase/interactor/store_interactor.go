@@ -0,0 +1,157 @@
+package interactor
+
+import (
+	"fmt"
+	"strings"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+type StoreInteractor struct {
+	storeRepo       repository.StoreRepository
+	reviewRepo      repository.ReviewRepository
+	ratingStatsRepo repository.RatingStatsRepository
+}
+
+func NewStoreInteractor(storeRepo repository.StoreRepository, reviewRepo repository.ReviewRepository, ratingStatsRepo repository.RatingStatsRepository) interfaces.StoreUseCase {
+	return &StoreInteractor{
+		storeRepo:       storeRepo,
+		reviewRepo:      reviewRepo,
+		ratingStatsRepo: ratingStatsRepo,
+	}
+}
+
+func (i *StoreInteractor) CreateStore(req *entity.StoreRequest, actor *entity.Actor) (*entity.Store, error) {
+	if !actor.Can(entity.PermissionManageStores) {
+		return nil, forbidden(resourceStore, 0, entity.ActionCreate, actor)
+	}
+
+	store := &entity.Store{}
+	applyStoreRequest(store, req)
+	if err := i.checkStoreNames(store); err != nil {
+		return nil, err
+	}
+
+	if err := i.storeRepo.Create(store); err != nil {
+		return nil, fmt.Errorf("店舗の作成に失敗しました: %w", err)
+	}
+	return store, nil
+}
+
+func (i *StoreInteractor) GetStoreByID(id uint) (*entity.StoreDetail, error) {
+	store, err := i.storeRepo.GetByID(id)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceStore}
+	}
+
+	stats, err := ratingSummary(i.ratingStatsRepo, entity.RatingSubjectStore, id)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.StoreDetail{Store: store, Stats: *stats}, nil
+}
+
+func (i *StoreInteractor) ListStores(keyword string, page entity.PageRequest) ([]*entity.Store, *entity.PageInfo, error) {
+	stores, pageInfo, err := i.storeRepo.List(keyword, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("店舗一覧の取得に失敗しました: %w", err)
+	}
+	return stores, pageInfo, nil
+}
+
+func (i *StoreInteractor) GetReviewsByStoreID(id uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error) {
+	if _, err := i.storeRepo.GetByID(id); err != nil {
+		return nil, nil, &entity.NotFoundError{Resource: resourceStore}
+	}
+
+	reviews, pageInfo, err := i.reviewRepo.GetReviewsByStoreID(id, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("店舗のレビュー一覧の取得に失敗しました: %w", err)
+	}
+	return reviews, pageInfo, nil
+}
+
+func (i *StoreInteractor) UpdateStore(id uint, req *entity.StoreRequest, actor *entity.Actor) (*entity.Store, error) {
+	if !actor.Can(entity.PermissionManageStores) {
+		return nil, forbidden(resourceStore, id, entity.ActionEdit, actor)
+	}
+
+	store, err := i.storeRepo.GetByID(id)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceStore}
+	}
+
+	applyStoreRequest(store, req)
+	if err := i.checkStoreNames(store); err != nil {
+		return nil, err
+	}
+
+	if err := i.storeRepo.Update(store); err != nil {
+		return nil, fmt.Errorf("店舗の更新に失敗しました: %w", err)
+	}
+	return store, nil
+}
+
+func (i *StoreInteractor) DeleteStore(id uint, actor *entity.Actor) error {
+	if !actor.Can(entity.PermissionManageStores) {
+		return forbidden(resourceStore, id, entity.ActionDelete, actor)
+	}
+
+	if _, err := i.storeRepo.GetByID(id); err != nil {
+		return &entity.NotFoundError{Resource: resourceStore}
+	}
+
+	count, err := i.storeRepo.CountReviews(id)
+	if err != nil {
+		return fmt.Errorf("店舗のレビュー件数の取得に失敗しました: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: レビューが投稿されている店舗は削除できません", entity.ErrConflict)
+	}
+
+	if err := i.storeRepo.Delete(id); err != nil {
+		return fmt.Errorf("店舗の削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// applyStoreRequest リクエストの内容を店舗に反映する（別名は置き換え、重複は除く）
+func applyStoreRequest(store *entity.Store, req *entity.StoreRequest) {
+	store.Name = strings.TrimSpace(req.Name)
+	store.Chain = req.Chain
+	store.Address = req.Address
+	store.Phone = req.Phone
+	store.Latitude = req.Latitude
+	store.Longitude = req.Longitude
+
+	seen := map[string]bool{entity.NormalizeStoreName(store.Name): true}
+	store.Aliases = nil
+	for _, alias := range req.Aliases {
+		normalized := entity.NormalizeStoreName(alias)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		store.Aliases = append(store.Aliases, entity.StoreAlias{Name: strings.TrimSpace(alias)})
+	}
+}
+
+// checkStoreNames 店舗名・別名が他の店舗の名前や別名と重複していないか確認する
+func (i *StoreInteractor) checkStoreNames(store *entity.Store) error {
+	if entity.NormalizeStoreName(store.Name) == "" {
+		return fmt.Errorf("%w: 店舗名が正しくありません", entity.ErrInvalidRequest)
+	}
+
+	for _, name := range store.Names() {
+		existing, err := i.storeRepo.FindByName(name)
+		if err != nil {
+			return fmt.Errorf("店舗の取得に失敗しました: %w", err)
+		}
+		if existing != nil && existing.ID != store.ID {
+			return fmt.Errorf("%w: 「%s」は店舗「%s」の名前として登録されています", entity.ErrConflict, name, existing.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package interactor
+
+import (
+	"fmt"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+type TrendingInteractor struct {
+	reviewRepo repository.ReviewRepository
+	policy     entity.TrendingPolicy
+}
+
+func NewTrendingInteractor(reviewRepo repository.ReviewRepository, policy entity.TrendingPolicy) interfaces.TrendingUseCase {
+	return &TrendingInteractor{
+		reviewRepo: reviewRepo,
+		policy:     policy,
+	}
+}
+
+func (i *TrendingInteractor) RefreshTrendingScores() error {
+	if _, err := i.reviewRepo.RefreshTrendingScores(i.policy); err != nil {
+		return fmt.Errorf("トレンドスコアの更新に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package interactor
+
+import (
+	"errors"
+	"fmt"
+
+	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/domain/repository"
+	"sidemenulab-backend/internal/usecase/interfaces"
+)
+
+type UserInteractor struct {
+	userRepo            repository.UserRepository
+	refreshTokenRepo    repository.RefreshTokenRepository
+	tokenRevocationRepo repository.TokenRevocationRepository
+}
+
+func NewUserInteractor(userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, tokenRevocationRepo repository.TokenRevocationRepository) interfaces.UserUseCase {
+	return &UserInteractor{
+		userRepo:            userRepo,
+		refreshTokenRepo:    refreshTokenRepo,
+		tokenRevocationRepo: tokenRevocationRepo,
+	}
+}
+
+// UpdateUserRole ユーザーのロールを変更する
+// ロールはトークンに含まれるため、変更後は対象ユーザーのトークンをすべて失効させて再ログインさせる
+func (i *UserInteractor) UpdateUserRole(userID uint, req *entity.UpdateUserRoleRequest, actor *entity.Actor) (*entity.User, error) {
+	if !actor.Can(entity.PermissionManageUsers) {
+		return nil, forbidden(resourceUser, userID, entity.ActionChangeRole, actor)
+	}
+	if !entity.IsValidRole(req.Role) {
+		return nil, errors.New("無効なロールです")
+	}
+	// 管理者が不在にならないよう、自分自身の降格は禁止する
+	if actor.UserID == userID && req.Role != entity.RoleAdmin {
+		return nil, errors.New("自分自身のロールは変更できません")
+	}
+
+	user, err := i.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceUser}
+	}
+	if user.Role == req.Role {
+		return user, nil
+	}
+
+	user.Role = req.Role
+	if err := i.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("ロールの更新に失敗しました: %w", err)
+	}
+
+	if err := revokeAllUserTokens(i.refreshTokenRepo, i.tokenRevocationRepo, user); err != nil {
+		return nil, fmt.Errorf("既存トークンの失効に失敗しました: %w", err)
+	}
+
+	return user, nil
+}
+
+// GrantAdminRole メールアドレス確認済みのユーザーに管理者ロールを付与する
+// 第三者が同じメールアドレスで登録したアカウントを昇格させないよう、未確認のユーザーには付与しない。
+func (i *UserInteractor) GrantAdminRole(email string) (*entity.User, error) {
+	user, err := i.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceUser}
+	}
+	if !user.IsEmailVerified() {
+		return nil, errors.New("メールアドレスが確認されていないユーザーには管理者ロールを付与できません")
+	}
+	if user.Role == entity.RoleAdmin {
+		return user, nil
+	}
+
+	user.Role = entity.RoleAdmin
+	if err := i.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("ロールの更新に失敗しました: %w", err)
+	}
+
+	if err := revokeAllUserTokens(i.refreshTokenRepo, i.tokenRevocationRepo, user); err != nil {
+		return nil, fmt.Errorf("既存トークンの失効に失敗しました: %w", err)
+	}
+
+	return user, nil
+}
+
+const (
+	defaultUserSearchLimit = 10
+	maxUserSearchLimit     = 20
+)
+
+func (i *UserInteractor) GetUserByHandle(handle string) (*entity.UserProfile, error) {
+	user, err := i.userRepo.GetByHandle(entity.NormalizeHandle(handle))
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceUser}
+	}
+	return user.Profile(), nil
+}
+
+func (i *UserInteractor) SearchUsersByHandle(query string, limit int) ([]*entity.UserProfile, error) {
+	if limit == 0 {
+		limit = defaultUserSearchLimit
+	}
+	if limit < 1 || limit > maxUserSearchLimit {
+		return nil, fmt.Errorf("%w: limit は1から%dの範囲で指定してください", entity.ErrInvalidCriteria, maxUserSearchLimit)
+	}
+
+	prefix := entity.NormalizeHandle(query)
+	if prefix == "" {
+		return []*entity.UserProfile{}, nil
+	}
+	if err := entity.ValidateHandlePrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	users, err := i.userRepo.SearchByHandlePrefix(prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの検索に失敗しました: %w", err)
+	}
+	profiles := make([]*entity.UserProfile, len(users))
+	for n, user := range users {
+		profiles[n] = user.Profile()
+	}
+	return profiles, nil
+}
+
+// UpdateHandle 自分のハンドルを変更する
+// 過去のメンションはユーザーIDで紐付いているため、変更後もリンクは保たれる。
+func (i *UserInteractor) UpdateHandle(userID uint, req *entity.UpdateHandleRequest) (*entity.User, error) {
+	handle := entity.NormalizeHandle(req.Handle)
+	if err := entity.ValidateHandle(handle); err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, &entity.NotFoundError{Resource: resourceUser}
+	}
+	if user.Handle == handle {
+		return user, nil
+	}
+	if existing, err := i.userRepo.GetByHandle(handle); err == nil && existing != nil {
+		return nil, fmt.Errorf("%w: このハンドルは既に使用されています", entity.ErrConflict)
+	}
+
+	user.Handle = handle
+	if err := i.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("ハンドルの更新に失敗しました: %w", err)
+	}
+	return user, nil
+}
@@ -1,8 +1,21 @@
 package interfaces
 
-import "sidemenulab-backend/internal/domain/entity"
+import (
+	"time"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
 
 type AuthUseCase interface {
 	SignUp(req *entity.SignUpRequest) (*entity.AuthResponse, error)
 	SignIn(req *entity.SignInRequest) (*entity.AuthResponse, error)
+	Refresh(req *entity.RefreshTokenRequest) (*entity.AuthResponse, error)
+	SignOut(userID uint, jti string, expiresAt time.Time, req *entity.SignOutRequest) error
+	SignOutAll(userID uint) error
+	ChangePassword(userID uint, req *entity.ChangePasswordRequest) (*entity.AuthResponse, error)
+	IsTokenRevoked(jti string, userID uint, generation uint64) (bool, error)
+	// IssueStreamTicket URL に載せてもよい、リアルタイム配信の接続専用の短命なチケットを発行する
+	IssueStreamTicket(userID uint) (*entity.StreamTicket, error)
+	// PurgeExpiredTokens 有効期限の切れたリフレッシュトークンと失効リストの行を削除する（定期ジョブ）
+	PurgeExpiredTokens() error
 }
@@ -0,0 +1,11 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// AuthorizationService レビュー・レビュー画像・コメントに対する操作権限を判定する
+// 画像とコメントは所属するレビューを解決した上で判定し、権限がない場合は *entity.ForbiddenError を返す。
+type AuthorizationService interface {
+	AuthorizeReview(reviewID uint, actor *entity.Actor, action string) (*entity.SideMenuReview, error)
+	AuthorizeReviewImage(imageID uint, actor *entity.Actor, action string) (*entity.SideMenuReviewImage, error)
+	AuthorizeReviewComment(commentID uint, actor *entity.Actor, action string) (*entity.ReviewComment, error)
+}
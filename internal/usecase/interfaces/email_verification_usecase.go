@@ -0,0 +1,15 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type EmailVerificationUseCase interface {
+	SendVerificationEmail(user *entity.User) error
+	VerifyEmail(req *entity.VerifyEmailRequest) error
+	ResendVerificationEmail(userID uint) error
+}
+
+// EmailVerificationPolicy メールアドレス未確認ユーザーの投稿可否を判定するポリシー
+type EmailVerificationPolicy interface {
+	// CheckCanPost 投稿できない場合は entity.ErrEmailNotVerified を返す
+	CheckCanPost(userID uint) error
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// EventPublisher ユースケースからリアルタイム配信のイベントを発行する（実装はインフラ層の realtime パッケージ）
+type EventPublisher interface {
+	// Publish 購読者がいなければ何もしない
+	Publish(topic string, event *entity.Event)
+}
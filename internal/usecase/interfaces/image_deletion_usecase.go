@@ -0,0 +1,7 @@
+package interfaces
+
+// ImageDeletionUseCase 削除された画像を保存先から削除する
+type ImageDeletionUseCase interface {
+	// ProcessImageDeletions 実行時刻になった削除ジョブを処理する（定期ジョブから呼ぶ）
+	ProcessImageDeletions() error
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// ImageGCUseCase どの画像の行からも参照されていない保存先の画像を回収する
+type ImageGCUseCase interface {
+	// CollectOrphanedImages 参照されていない画像を探して削除（DryRun の場合は報告のみ）する
+	// CLIのサブコマンドと定期ジョブから呼ぶ。
+	CollectOrphanedImages(options entity.ImageGCOptions) (*entity.ImageGCReport, error)
+}
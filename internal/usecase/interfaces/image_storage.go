@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ImageFolderRoot レビュー画像を保存するフォルダの最上位（年ごとのフォルダはこの配下に作る）
+const ImageFolderRoot = "sidemenulab/reviews"
+
+// StoredImage 保存した画像の情報
+type StoredImage struct {
+	// Key 削除やURL生成に使う保存先のキー（CloudinaryではPublicID、ローカルでは相対パス）
+	Key    string
+	URL    string
+	Width  int
+	Height int
+	Format string
+	Bytes  int
+}
+
+// StoredAsset 保存先にある画像（List の結果）
+type StoredAsset struct {
+	Key       string
+	Bytes     int
+	CreatedAt time.Time
+}
+
+// ImageStorage 画像の保存先（実装はインフラ層の storage パッケージ）
+type ImageStorage interface {
+	// Put 画像を folder 配下に name で保存する
+	Put(ctx context.Context, file io.Reader, folder string, name string) (*StoredImage, error)
+	// Delete Put が返したキーの画像を削除する（存在しない場合も成功とする）
+	Delete(ctx context.Context, key string) error
+	// URL キーから配信用のURLを返す
+	URL(key string) (string, error)
+	// List キーが prefix で始まる画像をすべて返す
+	List(ctx context.Context, prefix string) ([]*StoredAsset, error)
+	// Provider 保存先の種類（entity.StorageProviderCloudinary / entity.StorageProviderLocal）
+	Provider() string
+}
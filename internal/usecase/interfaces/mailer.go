@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"sidemenulab-backend/internal/domain/entity"
+)
+
+// Mailer ユースケースからメールを送信する（実装はインフラ層の mailer パッケージ）
+type Mailer interface {
+	Send(ctx context.Context, msg *entity.MailMessage) error
+}
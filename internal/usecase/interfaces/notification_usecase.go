@@ -0,0 +1,20 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+// NotificationPublisher ユースケースから通知を発行する
+// 自分自身の操作による通知や、受信設定で無効になっている種類の通知は作成せず false を返す。
+type NotificationPublisher interface {
+	Publish(notification *entity.Notification) (bool, error)
+}
+
+type NotificationUseCase interface {
+	ListNotifications(userID uint, unreadOnly bool, page entity.PageRequest) ([]*entity.Notification, *entity.PageInfo, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(userID uint, id uint) error
+	MarkAllRead(userID uint) (int64, error)
+	// GetPreferences すべての種類について受信するかどうかを返す
+	GetPreferences(userID uint) (entity.NotificationPreferences, error)
+	// UpdatePreferences 指定した種類の受信設定のみを変更する
+	UpdatePreferences(userID uint, prefs entity.NotificationPreferences) (entity.NotificationPreferences, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type PasswordResetUseCase interface {
+	ForgotPassword(req *entity.ForgotPasswordRequest) error
+	ResetPassword(req *entity.ResetPasswordRequest) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type RankingUseCase interface {
+	// GetSideMenuRanking 評価の高いサイドメニュー（category が空の場合は全カテゴリ）
+	GetSideMenuRanking(category string, limit int) ([]*entity.SideMenuRanking, *entity.Rankings, error)
+	// GetStoreRanking 評価の高い店舗（category を指定した場合はそのカテゴリのサイドメニューの評価で順位付けする）
+	GetStoreRanking(category string, limit int) ([]*entity.StoreRanking, *entity.Rankings, error)
+	// RefreshRankings ランキングを再計算してキャッシュを差し替える（定期ジョブから呼ぶ）
+	RefreshRankings() error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type ReactionUseCase interface {
+	ListReactionTypes() []entity.ReactionTypeInfo
+	// ToggleReaction リアクションが付いていれば取り消し、付いていなければ付ける
+	ToggleReaction(targetType entity.ReactionTargetType, targetID uint, reactionType entity.ReactionType, userID uint) (*entity.ReactionToggleResult, error)
+	// AttachReviewReactions 各レビューにリアクションの件数を設定する（userID が 0 以外の場合はそのユーザーのリアクションも設定する）
+	AttachReviewReactions(reviews []*entity.SideMenuReview, userID uint) error
+	// AttachCommentReactions 各コメントにリアクションの件数を設定する（userID が 0 以外の場合はそのユーザーのリアクションも設定する）
+	AttachCommentReactions(comments []*entity.ReviewComment, userID uint) error
+}
@@ -5,9 +5,13 @@ import "sidemenulab-backend/internal/domain/entity"
 type ReviewCommentUseCase interface {
 	CreateReviewComment(req *entity.CreateReviewCommentRequest, userID uint) (*entity.ReviewComment, error)
 	GetReviewCommentByID(id uint) (*entity.ReviewComment, error)
-	GetReviewCommentsByReviewID(reviewID uint) ([]*entity.ReviewComment, error)
-	GetReviewCommentsByUserID(userID uint) ([]*entity.ReviewComment, error)
-	GetAllReviewComments() ([]*entity.ReviewComment, error)
-	UpdateReviewComment(comment *entity.ReviewComment) error
-	DeleteReviewComment(id uint) error
+	// GetReviewCommentsByReviewID tree の場合はトップレベルのコメント単位でページングし、返信を入れ子にして返す
+	GetReviewCommentsByReviewID(reviewID uint, mode entity.ReviewCommentMode, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	GetReplies(commentID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	GetReviewCommentsByUserID(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	// GetMentionsOfUser ユーザーをメンションしているコメントを新しい順に返す
+	GetMentionsOfUser(userID uint, page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	GetAllReviewComments(page entity.PageRequest) ([]*entity.ReviewComment, *entity.PageInfo, error)
+	UpdateReviewComment(id uint, req *entity.UpdateReviewCommentRequest, actor *entity.Actor) (*entity.ReviewComment, error)
+	DeleteReviewComment(id uint, actor *entity.Actor) error
 }
@@ -6,16 +6,22 @@ type ReviewUseCase interface {
 	CreateReview(req *entity.CreateReviewRequest) (*entity.SideMenuReview, error)
 	CreateReviewWithUserID(req *entity.CreateReviewRequest, userID uint) (*entity.SideMenuReview, error)
 	GetReviewByID(id uint) (*entity.SideMenuReview, error)
-	GetReviewsByStoreName(storeName string) ([]*entity.SideMenuReview, error)
-	GetReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error)
-	GetAllReviews() ([]*entity.SideMenuReview, error)
-	GetLikedReviewsByUserID(userID uint) ([]*entity.SideMenuReview, error)
-	UpdateReview(review *entity.SideMenuReview) error
-	DeleteReview(id uint) error
-	CreateReviewImage(req *entity.CreateReviewImageRequest) (*entity.SideMenuReviewImage, error)
+	GetReviewsByStoreName(storeName string, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	GetReviewsBySideMenuID(sideMenuID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	GetReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	SearchReviews(criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	GetLikedReviewsByUserID(userID uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	UpdateReview(id uint, req *entity.CreateReviewRequest, actor *entity.Actor) (*entity.SideMenuReview, error)
+	DeleteReview(id uint, actor *entity.Actor) error
+	AuthorizeReviewImageUpload(reviewID uint, actor *entity.Actor) error
+	CreateReviewImage(req *entity.CreateReviewImageRequest, actor *entity.Actor) (*entity.SideMenuReviewImage, error)
 	GetReviewImagesByReviewID(reviewID uint) ([]*entity.SideMenuReviewImage, error)
-	DeleteReviewImage(imageID uint) error
-	CreateReviewLike(reviewID uint, userID uint) (*entity.SideMenuReviewLike, error)
+	DeleteReviewImage(imageID uint, actor *entity.Actor) error
+	// CreateReviewLike レビューにいいねする。新しく登録した場合は true を返し、既にいいねしている場合は既存のいいねと false を返す
+	CreateReviewLike(reviewID uint, userID uint) (*entity.SideMenuReviewLike, bool, error)
+	// DeleteReviewLike いいねを取り消す（いいねしていない場合も成功とする）
 	DeleteReviewLike(reviewID uint, userID uint) error
-	GetReviewLikesByReviewID(reviewID uint) ([]*entity.SideMenuReviewLike, error)
+	// MarkLikedByUser 各レビューの LikedByMe をユーザーのいいねの状態で設定する
+	MarkLikedByUser(reviews []*entity.SideMenuReview, userID uint) error
+	GetReviewLikesByReviewID(reviewID uint, page entity.PageRequest) ([]*entity.SideMenuReviewLike, *entity.PageInfo, error)
 }
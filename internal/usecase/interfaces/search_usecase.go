@@ -0,0 +1,8 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type SearchUseCase interface {
+	// SearchReviews キーワードでレビューを検索し、一致箇所をハイライトして返す
+	SearchReviews(keyword string, criteria entity.ReviewCriteria, page entity.PageRequest) ([]*entity.ReviewSearchHit, *entity.PageInfo, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type SideMenuUseCase interface {
+	CreateSideMenu(req *entity.SideMenuRequest, actor *entity.Actor) (*entity.SideMenu, error)
+	// GetSideMenuByID サイドメニューをレビューの集計値と一緒に取得する
+	GetSideMenuByID(id uint) (*entity.SideMenuDetail, error)
+	ListSideMenus(filter entity.SideMenuFilter, page entity.PageRequest) ([]*entity.SideMenu, *entity.PageInfo, error)
+	UpdateSideMenu(id uint, req *entity.SideMenuRequest, actor *entity.Actor) (*entity.SideMenu, error)
+	// DeleteSideMenu レビューが紐付いているサイドメニューは削除できない
+	DeleteSideMenu(id uint, actor *entity.Actor) error
+}
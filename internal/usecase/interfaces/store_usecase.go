@@ -0,0 +1,14 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type StoreUseCase interface {
+	CreateStore(req *entity.StoreRequest, actor *entity.Actor) (*entity.Store, error)
+	// GetStoreByID 店舗をレビューの集計値と一緒に取得する
+	GetStoreByID(id uint) (*entity.StoreDetail, error)
+	ListStores(keyword string, page entity.PageRequest) ([]*entity.Store, *entity.PageInfo, error)
+	GetReviewsByStoreID(id uint, page entity.PageRequest) ([]*entity.SideMenuReview, *entity.PageInfo, error)
+	UpdateStore(id uint, req *entity.StoreRequest, actor *entity.Actor) (*entity.Store, error)
+	// DeleteStore レビューが紐付いている店舗は削除できない
+	DeleteStore(id uint, actor *entity.Actor) error
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+// TrendingUseCase トレンドスコアの更新
+// トレンドの一覧は ReviewUseCase.SearchReviews に並び順 trending を指定して取得する。
+type TrendingUseCase interface {
+	// RefreshTrendingScores トレンドスコアを計算し直す（定期ジョブから呼ぶ）
+	RefreshTrendingScores() error
+}
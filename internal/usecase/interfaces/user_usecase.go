@@ -0,0 +1,14 @@
+package interfaces
+
+import "sidemenulab-backend/internal/domain/entity"
+
+type UserUseCase interface {
+	UpdateUserRole(userID uint, req *entity.UpdateUserRoleRequest, actor *entity.Actor) (*entity.User, error)
+	// GrantAdminRole メールアドレス確認済みのユーザーに管理者ロールを付与する
+	// 権限の確認を行わないため、サーバー管理者が実行するサブコマンドからのみ呼び出す。
+	GrantAdminRole(email string) (*entity.User, error)
+	GetUserByHandle(handle string) (*entity.UserProfile, error)
+	// SearchUsersByHandle メンション入力の補完向けに、ハンドルの前方一致でユーザーを探す
+	SearchUsersByHandle(query string, limit int) ([]*entity.UserProfile, error)
+	UpdateHandle(userID uint, req *entity.UpdateHandleRequest) (*entity.User, error)
+}
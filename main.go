@@ -1,15 +1,28 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
+	"sidemenulab-backend/internal/delivery/http/middleware"
 	deliveryhttp "sidemenulab-backend/internal/delivery/http"
 	"sidemenulab-backend/internal/domain/entity"
+	"sidemenulab-backend/internal/infrastructure/cache"
 	"sidemenulab-backend/internal/infrastructure/cloudinary"
 	"sidemenulab-backend/internal/infrastructure/database"
+	"sidemenulab-backend/internal/infrastructure/mailer"
+	"sidemenulab-backend/internal/infrastructure/realtime"
+	"sidemenulab-backend/internal/infrastructure/scheduler"
+	"sidemenulab-backend/internal/infrastructure/storage"
 	"sidemenulab-backend/internal/usecase/interactor"
+	"sidemenulab-backend/internal/usecase/interfaces"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -48,13 +61,7 @@ func main() {
 	defer sqlDB.Close()
 
 	// データベースマイグレーション
-	if err := db.AutoMigrate(
-		&entity.User{},
-		&entity.SideMenuReview{},
-		&entity.SideMenuReviewImage{},
-		&entity.SideMenuReviewLike{},
-		&entity.ReviewComment{},
-	); err != nil {
+	if err := database.Migrate(db); err != nil {
 		log.Fatal("データベースマイグレーションに失敗しました:", err)
 	}
 
@@ -65,16 +72,115 @@ func main() {
 
 	// 依存性注入
 	userRepo := database.NewUserRepository(db)
+	refreshTokenRepo := database.NewRefreshTokenRepository(db)
+	// 失効リストは認証のたびに参照するため、インメモリキャッシュを前段に置く
+	tokenRevocationRepo := cache.NewTokenRevocationCache(database.NewTokenRevocationRepository(db), 30*time.Second)
+	passwordResetTokenRepo := database.NewPasswordResetTokenRepository(db)
+	emailVerificationTokenRepo := database.NewEmailVerificationTokenRepository(db)
+	storeRepo := database.NewStoreRepository(db)
+	sideMenuRepo := database.NewSideMenuRepository(db)
 	reviewRepo := database.NewReviewRepository(db)
+	imageDeletionJobRepo := database.NewImageDeletionJobRepository(db)
 	reviewCommentRepo := database.NewReviewCommentRepository(db)
+	reactionRepo := database.NewReactionRepository(db)
+	notificationRepo := database.NewNotificationRepository(db)
+	reviewSearchRepo := database.NewReviewSearchRepository(db)
+	ratingStatsRepo := database.NewRatingStatsRepository(db)
+	rankingRepo := database.NewRankingRepository(db)
 	
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		jwtSecret = "your-secret-key" // 本番環境では必ず環境変数で設定してください
 	}
-	authUseCase := interactor.NewAuthInteractor(userRepo, jwtSecret)
-	reviewUseCase := interactor.NewReviewInteractor(reviewRepo)
-	reviewCommentUseCase := interactor.NewReviewCommentInteractor(reviewCommentRepo)
+	// メール送信の初期化（MAIL_DRIVER=smtp 以外はログ出力のみ）
+	// ログ出力はローカル開発・テスト用のため、本番環境（GIN_MODE=release）では SMTP の設定を必須とする
+	var mail interfaces.Mailer
+	if os.Getenv("MAIL_DRIVER") == "smtp" {
+		smtpHost := os.Getenv("SMTP_HOST")
+		mailFrom := os.Getenv("MAIL_FROM")
+		if smtpHost == "" || mailFrom == "" {
+			log.Fatal("MAIL_DRIVER=smtp の場合は SMTP_HOST と MAIL_FROM を設定してください")
+		}
+		smtpPort := os.Getenv("SMTP_PORT")
+		if smtpPort == "" {
+			smtpPort = "587"
+		}
+		mail = mailer.NewSMTPMailer(smtpHost, smtpPort, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), mailFrom)
+		log.Println("SMTPでメールを送信します")
+	} else if env == "release" {
+		log.Fatal("本番環境ではメールを送信するため MAIL_DRIVER=smtp を設定してください")
+	} else {
+		mail = mailer.NewLogMailer(os.Getenv("MAIL_LOG_DIR"))
+		log.Println("メールは送信せずログに出力します")
+	}
+
+	passwordResetURL := os.Getenv("PASSWORD_RESET_URL")
+	if passwordResetURL == "" {
+		passwordResetURL = "http://localhost:3000/reset-password"
+	}
+
+	verifyEmailURL := os.Getenv("VERIFY_EMAIL_URL")
+	if verifyEmailURL == "" {
+		verifyEmailURL = "http://localhost:3000/verify-email"
+	}
+
+	// REQUIRE_EMAIL_VERIFICATION=true の場合、メールアドレス未確認ユーザーはレビュー・コメントを投稿できない
+	requireEmailVerification := os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true"
+	emailVerificationPolicy := interactor.NewEmailVerificationPolicy(userRepo, requireEmailVerification)
+
+	emailVerificationUseCase := interactor.NewEmailVerificationInteractor(userRepo, emailVerificationTokenRepo, mail, verifyEmailURL)
+	authUseCase := interactor.NewAuthInteractor(userRepo, refreshTokenRepo, tokenRevocationRepo, emailVerificationUseCase, jwtSecret)
+	passwordResetUseCase := interactor.NewPasswordResetInteractor(userRepo, passwordResetTokenRepo, refreshTokenRepo, tokenRevocationRepo, mail, passwordResetURL)
+	authorizationService := interactor.NewAuthorizationService(reviewRepo, reviewCommentRepo)
+	// リアルタイム配信はプロセス内で完結させる（複数インスタンス構成ではブローカーの実装に差し替える）
+	eventHub := realtime.NewMemoryHub()
+	notificationPublisher := interactor.NewNotificationService(notificationRepo, userRepo, eventHub)
+	userUseCase := interactor.NewUserInteractor(userRepo, refreshTokenRepo, tokenRevocationRepo)
+	storeUseCase := interactor.NewStoreInteractor(storeRepo, reviewRepo, ratingStatsRepo)
+	sideMenuUseCase := interactor.NewSideMenuInteractor(sideMenuRepo, storeRepo, ratingStatsRepo)
+
+	// トレンドスコアは TRENDING_HALF_LIFE ごとに半減し、TRENDING_REFRESH_INTERVAL ごとに再計算する
+	trendingPolicy := entity.DefaultTrendingPolicy()
+	if v, err := time.ParseDuration(os.Getenv("TRENDING_HALF_LIFE")); err == nil && v > 0 {
+		trendingPolicy.HalfLife = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("TRENDING_LIKE_WEIGHT"), 64); err == nil && v >= 0 {
+		trendingPolicy.LikeWeight = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("TRENDING_COMMENT_WEIGHT"), 64); err == nil && v >= 0 {
+		trendingPolicy.CommentWeight = v
+	}
+	trendingRefreshInterval := 5 * time.Minute
+	if v, err := time.ParseDuration(os.Getenv("TRENDING_REFRESH_INTERVAL")); err == nil && v > 0 {
+		trendingRefreshInterval = v
+	}
+	trendingUseCase := interactor.NewTrendingInteractor(reviewRepo, trendingPolicy)
+
+	reviewUseCase := interactor.NewReviewInteractor(reviewRepo, storeRepo, sideMenuRepo, emailVerificationPolicy, authorizationService, notificationPublisher, eventHub, trendingPolicy)
+	reviewCommentUseCase := interactor.NewReviewCommentInteractor(reviewCommentRepo, userRepo, emailVerificationPolicy, authorizationService, notificationPublisher, eventHub)
+	reactionUseCase := interactor.NewReactionInteractor(reactionRepo, reviewRepo, reviewCommentRepo)
+	notificationUseCase := interactor.NewNotificationInteractor(notificationRepo)
+	// 検索の表記ゆれは組み込みの辞書に SEARCH_VARIANTS_FILE（JSON: [["からあげ", "唐揚げ"], ...]）のグループを加える
+	searchVariantGroups := entity.DefaultSearchVariantGroups()
+	if path := os.Getenv("SEARCH_VARIANTS_FILE"); path != "" {
+		groups, err := loadSearchVariantGroups(path)
+		if err != nil {
+			log.Fatal("表記ゆれの辞書の読み込みに失敗しました:", err)
+		}
+		searchVariantGroups = append(searchVariantGroups, groups...)
+	}
+	searchUseCase := interactor.NewSearchInteractor(reviewSearchRepo, entity.NewSearchVariants(searchVariantGroups))
+
+	// ランキングはレビュー件数が RANKING_MIN_REVIEWS 件以上の対象のみ載せ、RANKING_REFRESH_INTERVAL ごとに再計算する
+	rankingMinReviews := 3
+	if v, err := strconv.Atoi(os.Getenv("RANKING_MIN_REVIEWS")); err == nil && v > 0 {
+		rankingMinReviews = v
+	}
+	rankingRefreshInterval := 10 * time.Minute
+	if v, err := time.ParseDuration(os.Getenv("RANKING_REFRESH_INTERVAL")); err == nil && v > 0 {
+		rankingRefreshInterval = v
+	}
+	rankingUseCase := interactor.NewRankingInteractor(rankingRepo, ratingStatsRepo, rankingMinReviews)
 
 	// Cloudinaryサービスの初期化
 	cloudName := os.Getenv("CLOUDINARY_CLOUD_NAME")
@@ -86,25 +192,99 @@ func main() {
 		func() string { if apiKey != "" { return apiKey[:8] + "..." } else { return "未設定" } }(), 
 		func() string { if apiSecret != "" { return apiSecret[:8] + "..." } else { return "未設定" } }())
 
-	var cloudinaryService *cloudinary.CloudinaryService
-	if cloudName != "" && apiKey != "" && apiSecret != "" {
-		var err error
-		cloudinaryService, err = cloudinary.NewCloudinaryService(cloudName, apiKey, apiSecret)
+	// 画像の保存先の初期化（IMAGE_STORAGE 未指定時はCloudinaryの環境変数があればCloudinary、なければローカル）
+	// 指定した保存先を使えない場合は、別の保存先に黙って切り替えず起動を中止する
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "./uploads"
+	}
+
+	storageDriver := os.Getenv("IMAGE_STORAGE")
+	if storageDriver == "" {
+		storageDriver = "local"
+		if cloudName != "" && apiKey != "" && apiSecret != "" {
+			storageDriver = "cloudinary"
+		}
+	}
+
+	var imageStorage interfaces.ImageStorage
+	switch storageDriver {
+	case "cloudinary":
+		cloudinaryService, err := cloudinary.NewCloudinaryService(cloudName, apiKey, apiSecret)
 		if err != nil {
-			log.Printf("Cloudinaryサービスの初期化に失敗しました: %v", err)
-			log.Println("Cloudinaryが利用できないため、ローカルファイルアップロードを使用します")
-		} else {
-			log.Println("Cloudinaryサービスが正常に初期化されました")
+			log.Fatalf("Cloudinaryサービスの初期化に失敗しました: %v", err)
 		}
-	} else {
-		log.Println("Cloudinaryの環境変数が設定されていないため、ローカルファイルアップロードを使用します")
+		log.Println("Cloudinaryサービスが正常に初期化されました")
+		imageStorage = storage.NewCloudinaryStorage(cloudinaryService)
+	case "local":
+		// 本番環境では localhost の URL を画像に保存しないよう、配信 URL の設定を必須とする
+		uploadBaseURL := os.Getenv("UPLOAD_BASE_URL")
+		if uploadBaseURL == "" {
+			if env == "release" {
+				log.Fatal("本番環境で IMAGE_STORAGE=local を使う場合は UPLOAD_BASE_URL を設定してください")
+			}
+			uploadBaseURL = "http://localhost:8080/uploads"
+		}
+		log.Println("ローカルファイルアップロードを使用します")
+		imageStorage = storage.NewLocalStorage(uploadDir, uploadBaseURL)
+	default:
+		log.Fatalf("IMAGE_STORAGE=%s はサポートされていません（cloudinary または local を指定してください）", storageDriver)
+	}
+
+	// 削除された画像は IMAGE_DELETION_INTERVAL ごとに保存先から削除する（失敗した場合は間隔を空けて再試行する）
+	imageDeletionInterval := time.Minute
+	if v, err := time.ParseDuration(os.Getenv("IMAGE_DELETION_INTERVAL")); err == nil && v > 0 {
+		imageDeletionInterval = v
+	}
+	imageDeletionUseCase := interactor.NewImageDeletionInteractor(imageDeletionJobRepo, reviewRepo, imageStorage)
+
+	// 参照されていない保存先の画像は IMAGE_GC_INTERVAL ごとに回収する（IMAGE_GC_DRY_RUN=false を指定した場合だけ削除し、それ以外は報告のみ）
+	imageGCOptions := entity.DefaultImageGCOptions()
+	if v, err := time.ParseDuration(os.Getenv("IMAGE_GC_GRACE_PERIOD")); err == nil && v > 0 {
+		imageGCOptions.GracePeriod = v
+	}
+	if os.Getenv("IMAGE_GC_DRY_RUN") == "false" {
+		imageGCOptions.DryRun = false
 	}
+	imageGCInterval := 24 * time.Hour
+	if v, err := time.ParseDuration(os.Getenv("IMAGE_GC_INTERVAL")); err == nil && v > 0 {
+		imageGCInterval = v
+	}
+	imageGCUseCase := interactor.NewImageGCInteractor(reviewRepo, imageDeletionJobRepo, imageStorage)
 
-	// Ginエンジンの初期化
-	engine := gin.Default()
+	// サブコマンドが指定された場合はサーバーを起動せずに実行する（例: go run main.go gc-images [-dry-run=false] [-grace-period 24h]）
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:], userUseCase, imageGCUseCase, imageGCOptions); err != nil {
+			log.Fatal("サブコマンドの実行に失敗しました:", err)
+		}
+		return
+	}
+
+	// 期限切れのリフレッシュトークンと失効リストは TOKEN_PURGE_INTERVAL ごとに削除する
+	tokenPurgeInterval := time.Hour
+	if v, err := time.ParseDuration(os.Getenv("TOKEN_PURGE_INTERVAL")); err == nil && v > 0 {
+		tokenPurgeInterval = v
+	}
+
+	// 定期ジョブ
+	jobs := scheduler.New()
+	jobs.Every("rankings", rankingRefreshInterval, rankingUseCase.RefreshRankings)
+	jobs.Every("trending", trendingRefreshInterval, trendingUseCase.RefreshTrendingScores)
+	jobs.Every("token-purge", tokenPurgeInterval, authUseCase.PurgeExpiredTokens)
+	jobs.Every("image-deletions", imageDeletionInterval, imageDeletionUseCase.ProcessImageDeletions)
+	jobs.Every("image-gc", imageGCInterval, func() error {
+		_, err := imageGCUseCase.CollectOrphanedImages(imageGCOptions)
+		return err
+	})
+	jobs.Start()
+	defer jobs.Stop()
+
+	// Ginエンジンの初期化（アクセスログは配信用チケットを記録しないものに差し替える）
+	engine := gin.New()
+	engine.Use(middleware.AccessLogger(), gin.Recovery())
 	
 	// 静的ファイルの配信設定
-	engine.Static("/uploads", "./uploads")
+	engine.Static("/uploads", uploadDir)
 	
 	// CORS設定
 	engine.Use(func(c *gin.Context) {
@@ -144,7 +324,7 @@ func main() {
 	})
 
 	// ルート設定
-	deliveryhttp.SetupRoutes(engine, authUseCase, reviewUseCase, reviewCommentUseCase, jwtSecret, cloudinaryService)
+	deliveryhttp.SetupRoutes(engine, authUseCase, passwordResetUseCase, emailVerificationUseCase, userUseCase, storeUseCase, sideMenuUseCase, reviewUseCase, reviewCommentUseCase, reactionUseCase, notificationUseCase, searchUseCase, rankingUseCase, eventHub, jwtSecret, imageStorage)
 
 	// サーバー起動
 	port := os.Getenv("PORT")
@@ -154,4 +334,61 @@ func main() {
 	
 	log.Printf("サーバーを起動中... ポート: %s", port)
 	engine.Run(":" + port)
-}
\ No newline at end of file
+}
+
+// loadSearchVariantGroups 表記ゆれのグループを JSON ファイルから読み込む
+func loadSearchVariantGroups(path string) ([][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var groups [][]string
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, fmt.Errorf("%s の形式が正しくありません: %w", path, err)
+	}
+	return groups, nil
+}
+
+// runCommand サーバーを起動せずに実行するサブコマンド
+//
+//	grant-admin -email <address>                    メールアドレス確認済みのユーザーに管理者ロールを付与する
+//	gc-images [-dry-run=false] [-grace-period 24h]  参照されていない保存先の画像を回収し、結果をJSONで出力する
+func runCommand(args []string, userUseCase interfaces.UserUseCase, imageGCUseCase interfaces.ImageGCUseCase, imageGCOptions entity.ImageGCOptions) error {
+	switch args[0] {
+	case "grant-admin":
+		flags := flag.NewFlagSet("grant-admin", flag.ContinueOnError)
+		email := flags.String("email", "", "管理者にするユーザーのメールアドレス")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *email == "" {
+			return errors.New("-email を指定してください")
+		}
+
+		user, err := userUseCase.GrantAdminRole(*email)
+		if err != nil {
+			return err
+		}
+		log.Printf("管理者ロールを付与しました - UserID: %d, Email: %s", user.ID, user.Email)
+		return nil
+	case "gc-images":
+		flags := flag.NewFlagSet("gc-images", flag.ContinueOnError)
+		flags.BoolVar(&imageGCOptions.DryRun, "dry-run", imageGCOptions.DryRun, "削除せずに報告のみ行う")
+		flags.DurationVar(&imageGCOptions.GracePeriod, "grace-period", imageGCOptions.GracePeriod, "アップロードからこの時間が経過していない画像は対象にしない")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		report, gcErr := imageGCUseCase.CollectOrphanedImages(imageGCOptions)
+		if report != nil {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				return err
+			}
+		}
+		return gcErr
+	default:
+		return fmt.Errorf("不明なサブコマンドです: %s", args[0])
+	}
+}